@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/sso"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	agreementNames        []string
+	agreementNamePatterns []string
+)
+
+const (
+	agreementsCmdName = "agreements"
+
+	agreementNamesParamName      = "agreement-name"
+	agreementNamesParamConfigKey = "pingone.services.sso.agreements.names"
+
+	agreementNamePatternsParamName      = "agreement-name-pattern"
+	agreementNamePatternsParamConfigKey = "pingone.services.sso.agreements.name-patterns"
+
+	agreementRulesConfigKey = "pingone.services.sso.agreements.rules"
+)
+
+var (
+	agreementConfigurationParamMapping = map[string]string{
+		agreementNamesParamName:        agreementNamesParamConfigKey,
+		agreementNamePatternsParamName: agreementNamePatternsParamConfigKey,
+	}
+)
+
+var cleanAgreementsCmd = &cobra.Command{
+	Use:   agreementsCmdName,
+	Short: "Clean unwanted sample agreements",
+	Long: fmt.Sprintf(`Clean away sample agreements, with their language versions and revisions, selected by name or name pattern.
+
+	Agreements used by the agreement action of a sign-on policy are reported as blocked and are not deleted.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Sample Terms of Service" --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "^Sample " --%s
+	
+	`, agreementsCmdName, environmentIDParamName, agreementNamesParamName, dryRunParamName, agreementsCmdName, environmentIDParamName, agreementNamePatternsParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		agreementNames := viper.GetStringSlice(agreementNamesParamConfigKey)
+		agreementNamePatterns := viper.GetStringSlice(agreementNamePatternsParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for agreements.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Agreement names: "%s"`, strings.Join(agreementNames, `", "`))
+		l.Debug().Msgf(`Agreement name patterns: "%s"`, strings.Join(agreementNamePatterns, `", "`))
+
+		patterns, err := compilePatterns(agreementNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(agreementRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := sso.CleanEnvironmentAgreementsConfig{
+			Environment:                environment,
+			BootstrapAgreementNames:    agreementNames,
+			BootstrapAgreementPatterns: patterns,
+			Rules:                      rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanAgreementsCmd.PersistentFlags().StringSliceVar(&agreementNames, agreementNamesParamName, []string{}, "The list of agreement names to search for to delete.")
+	cleanAgreementsCmd.PersistentFlags().StringSliceVar(&agreementNamePatterns, agreementNamePatternsParamName, []string{}, "The list of regular expressions to match agreement names to delete.")
+
+	if err := bindParams(agreementConfigurationParamMapping, cleanAgreementsCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
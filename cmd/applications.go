@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/sso"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	applicationNames        []string
+	applicationNamePatterns []string
+	applicationTypes        []string
+	applicationDisable      bool
+)
+
+const (
+	applicationsCmdName = "applications"
+
+	applicationNamesParamName      = "application-name"
+	applicationNamesParamConfigKey = "pingone.services.sso.applications.names"
+
+	applicationNamePatternsParamName      = "application-name-pattern"
+	applicationNamePatternsParamConfigKey = "pingone.services.sso.applications.name-patterns"
+
+	applicationTypesParamName      = "application-type"
+	applicationTypesParamConfigKey = "pingone.services.sso.applications.types"
+
+	applicationDisableParamName      = "disable"
+	applicationDisableParamConfigKey = "pingone.services.sso.applications.disable"
+
+	applicationRulesConfigKey = "pingone.services.sso.applications.rules"
+)
+
+var (
+	applicationConfigurationParamMapping = map[string]string{
+		applicationNamesParamName:        applicationNamesParamConfigKey,
+		applicationNamePatternsParamName: applicationNamePatternsParamConfigKey,
+		applicationTypesParamName:        applicationTypesParamConfigKey,
+		applicationDisableParamName:      applicationDisableParamConfigKey,
+	}
+)
+
+var cleanApplicationsCmd = &cobra.Command{
+	Use:   applicationsCmdName,
+	Short: "Clean unwanted demo application configuration",
+	Long: fmt.Sprintf(`Clean away demo configuration and prepare an environment for production-ready configuration.
+
+	PingOne system applications (the Admin Console, Self-Service and Application Portal) and the worker application running the sweep are never
+	modified.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Getting Started Application" --%s "^Sample " --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s SINGLE_PAGE_APP --%s --%s
+	
+	`, applicationsCmdName, environmentIDParamName, dryRunParamName, applicationsCmdName, environmentIDParamName, applicationNamesParamName, applicationNamePatternsParamName, dryRunParamName, applicationsCmdName, environmentIDParamName, applicationTypesParamName, applicationDisableParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		applicationNames := viper.GetStringSlice(applicationNamesParamConfigKey)
+		applicationNamePatterns := viper.GetStringSlice(applicationNamePatternsParamConfigKey)
+		applicationTypes := viper.GetStringSlice(applicationTypesParamConfigKey)
+		applicationDisable := viper.GetBool(applicationDisableParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for applications.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Application names: "%s"`, strings.Join(applicationNames, `", "`))
+		l.Debug().Msgf(`Application name patterns: "%s"`, strings.Join(applicationNamePatterns, `", "`))
+		l.Debug().Msgf(`Application types: "%s"`, strings.Join(applicationTypes, `", "`))
+		l.Debug().Msgf("Disable setting: %t", applicationDisable)
+
+		patterns, err := compilePatterns(applicationNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		types := make([]management.EnumApplicationType, 0, len(applicationTypes))
+		for _, applicationType := range applicationTypes {
+			t, err := management.NewEnumApplicationTypeFromValue(strings.ToUpper(applicationType))
+			if err != nil {
+				return err
+			}
+			types = append(types, *t)
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(applicationRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := sso.CleanEnvironmentApplicationsConfig{
+			Environment:                  environment,
+			BootstrapApplicationNames:    applicationNames,
+			BootstrapApplicationPatterns: patterns,
+			WorkerClientID:               viper.GetString(workerClientIDParamConfigKey),
+			ApplicationTypes:             types,
+			Disable:                      applicationDisable,
+			Rules:                        rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanApplicationsCmd.PersistentFlags().StringSliceVar(&applicationNames, applicationNamesParamName, sso.BootstrapApplicationNames, "The list of application names to search for to delete.")
+	cleanApplicationsCmd.PersistentFlags().StringSliceVar(&applicationNamePatterns, applicationNamePatternsParamName, []string{}, "The list of regular expressions to match application names to delete.")
+	cleanApplicationsCmd.PersistentFlags().StringSliceVar(&applicationTypes, applicationTypesParamName, []string{}, "The list of application types to delete (for example WEB_APP, SINGLE_PAGE_APP, NATIVE_APP, WORKER).")
+	cleanApplicationsCmd.PersistentFlags().BoolVar(&applicationDisable, applicationDisableParamName, false, "Disable matching applications instead of deleting them.  The resource grants of disabled applications are revoked, and their client secret is rotated where they have one, as deleting an application does.")
+
+	if err := bindParams(applicationConfigurationParamMapping, cleanApplicationsCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/clean/services/sso"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/spf13/cobra"
@@ -20,6 +19,8 @@ const (
 
 	authenticationPolicyNamesParamName      = "policy-name"
 	authenticationPolicyNamesParamConfigKey = "pingone.services.sso.authentication-policies.names"
+
+	authenticationPolicyRulesConfigKey = "pingone.services.sso.authentication-policies.rules"
 )
 
 var (
@@ -55,13 +56,20 @@ var cleanAuthenticationPoliciesCmd = &cobra.Command{
 			return err
 		}
 
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(authenticationPolicyRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
 		cleanConfig := sso.CleanEnvironmentAuthenticationPoliciesConfig{
-			Environment: clean.CleanEnvironmentConfig{
-				EnvironmentID: viper.GetString(environmentIDParamConfigKey),
-				DryRun:        dryRun,
-				Client:        apiClient.API,
-			},
+			Environment:                        environment,
 			BootstrapAuthenticationPolicyNames: authenticationPolicyNames,
+			Rules:                              rules,
 		}
 
 		return cleanConfig.Clean(cmd.Context())
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/authorize"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	authorizeAPIServiceNames              []string
+	authorizeAPIServiceNamePatterns       []string
+	authorizeDecisionEndpointNames        []string
+	authorizeDecisionEndpointNamePatterns []string
+)
+
+const (
+	authorizeCmdName = "authorize"
+
+	authorizeAPIServiceNamesParamName      = "api-service-name"
+	authorizeAPIServiceNamesParamConfigKey = "pingone.services.authorize.api-services.names"
+
+	authorizeAPIServiceNamePatternsParamName      = "api-service-name-pattern"
+	authorizeAPIServiceNamePatternsParamConfigKey = "pingone.services.authorize.api-services.name-patterns"
+
+	authorizeDecisionEndpointNamesParamName      = "decision-endpoint-name"
+	authorizeDecisionEndpointNamesParamConfigKey = "pingone.services.authorize.decision-endpoints.names"
+
+	authorizeDecisionEndpointNamePatternsParamName      = "decision-endpoint-name-pattern"
+	authorizeDecisionEndpointNamePatternsParamConfigKey = "pingone.services.authorize.decision-endpoints.name-patterns"
+
+	authorizeAPIServiceRulesConfigKey       = "pingone.services.authorize.api-services.rules"
+	authorizeDecisionEndpointRulesConfigKey = "pingone.services.authorize.decision-endpoints.rules"
+)
+
+var (
+	authorizeConfigurationParamMapping = map[string]string{
+		authorizeAPIServiceNamesParamName:              authorizeAPIServiceNamesParamConfigKey,
+		authorizeAPIServiceNamePatternsParamName:       authorizeAPIServiceNamePatternsParamConfigKey,
+		authorizeDecisionEndpointNamesParamName:        authorizeDecisionEndpointNamesParamConfigKey,
+		authorizeDecisionEndpointNamePatternsParamName: authorizeDecisionEndpointNamePatternsParamConfigKey,
+	}
+)
+
+var cleanAuthorizeCmd = &cobra.Command{
+	Use:   authorizeCmdName,
+	Short: "Clean unwanted demo and test Authorize API services and decision endpoints",
+	Long: fmt.Sprintf(`Clean away demo and test Authorize API services (with their operations) and policy decision endpoints, selected by name or name pattern.
+
+	Dependents are removed before their dependencies: the operations of an API service are removed before the API service, and API services are removed before decision endpoints.  PingOne-owned decision endpoints are never modified.
+
+	Application roles and permissions, and trust framework attributes and policies, are not swept by this command, as the Authorize SDK module in use does not expose them.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Test API" --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "^Demo .*" --%s "^Demo .*" --%s
+	
+	`, authorizeCmdName, environmentIDParamName, authorizeAPIServiceNamesParamName, dryRunParamName, authorizeCmdName, environmentIDParamName, authorizeAPIServiceNamePatternsParamName, authorizeDecisionEndpointNamePatternsParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		authorizeAPIServiceNames := viper.GetStringSlice(authorizeAPIServiceNamesParamConfigKey)
+		authorizeAPIServiceNamePatterns := viper.GetStringSlice(authorizeAPIServiceNamePatternsParamConfigKey)
+		authorizeDecisionEndpointNames := viper.GetStringSlice(authorizeDecisionEndpointNamesParamConfigKey)
+		authorizeDecisionEndpointNamePatterns := viper.GetStringSlice(authorizeDecisionEndpointNamePatternsParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for Authorize.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`API service names: "%s"`, strings.Join(authorizeAPIServiceNames, `", "`))
+		l.Debug().Msgf(`API service name patterns: "%s"`, strings.Join(authorizeAPIServiceNamePatterns, `", "`))
+		l.Debug().Msgf(`Decision endpoint names: "%s"`, strings.Join(authorizeDecisionEndpointNames, `", "`))
+		l.Debug().Msgf(`Decision endpoint name patterns: "%s"`, strings.Join(authorizeDecisionEndpointNamePatterns, `", "`))
+
+		apiServicePatterns, err := compilePatterns(authorizeAPIServiceNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		decisionEndpointPatterns, err := compilePatterns(authorizeDecisionEndpointNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		apiServiceRules, err := compileRules(authorizeAPIServiceRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		decisionEndpointRules, err := compileRules(authorizeDecisionEndpointRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		// API services are cleaned first, as they depend on decision endpoints
+		apiServicesConfig := authorize.CleanEnvironmentAuthorizeAPIServicesConfig{
+			Environment:                 environment,
+			BootstrapAPIServiceNames:    authorizeAPIServiceNames,
+			BootstrapAPIServicePatterns: apiServicePatterns,
+			Rules:                       apiServiceRules,
+		}
+
+		if err := apiServicesConfig.Clean(cmd.Context()); err != nil {
+			return err
+		}
+
+		decisionEndpointsConfig := authorize.CleanEnvironmentAuthorizeDecisionEndpointsConfig{
+			Environment:                       environment,
+			BootstrapDecisionEndpointNames:    authorizeDecisionEndpointNames,
+			BootstrapDecisionEndpointPatterns: decisionEndpointPatterns,
+			Rules:                             decisionEndpointRules,
+		}
+
+		return decisionEndpointsConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanAuthorizeCmd.PersistentFlags().StringSliceVar(&authorizeAPIServiceNames, authorizeAPIServiceNamesParamName, []string{}, "The list of Authorize API service names to search for to delete.")
+	cleanAuthorizeCmd.PersistentFlags().StringSliceVar(&authorizeAPIServiceNamePatterns, authorizeAPIServiceNamePatternsParamName, []string{}, "The list of regular expressions to match Authorize API service names to delete.")
+	cleanAuthorizeCmd.PersistentFlags().StringSliceVar(&authorizeDecisionEndpointNames, authorizeDecisionEndpointNamesParamName, []string{}, "The list of Authorize decision endpoint names to search for to delete.")
+	cleanAuthorizeCmd.PersistentFlags().StringSliceVar(&authorizeDecisionEndpointNamePatterns, authorizeDecisionEndpointNamePatternsParamName, []string{}, "The list of regular expressions to match Authorize decision endpoint names to delete.")
+
+	if err := bindParams(authorizeConfigurationParamMapping, cleanAuthorizeCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
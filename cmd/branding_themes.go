@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/spf13/cobra"
@@ -20,6 +19,8 @@ const (
 
 	brandingThemeNamesParamName      = "theme-name"
 	brandingThemeNamesParamConfigKey = "pingone.services.platform.branding-themes.names"
+
+	brandingThemeRulesConfigKey = "pingone.services.platform.branding-themes.rules"
 )
 
 var (
@@ -33,6 +34,8 @@ var cleanBrandingThemesCmd = &cobra.Command{
 	Short: "Clean unwanted demo branding theme configuration",
 	Long: fmt.Sprintf(`Clean away demo configuration and prepare an environment for production-ready configuration.
 
+	Branding themes have no description, so a theme can only be protected with the protection marker in its name.
+
 	Examples:
 	
 	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s
@@ -55,13 +58,20 @@ var cleanBrandingThemesCmd = &cobra.Command{
 			return err
 		}
 
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(brandingThemeRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
 		cleanConfig := platform.CleanEnvironmentPlatformBrandingThemesConfig{
-			Environment: clean.CleanEnvironmentConfig{
-				EnvironmentID: viper.GetString(environmentIDParamConfigKey),
-				DryRun:        dryRun,
-				Client:        apiClient.API,
-			},
+			Environment:                 environment,
 			BootstrapBrandingThemeNames: themeNames,
+			Rules:                       rules,
 		}
 
 		return cleanConfig.Clean(cmd.Context())
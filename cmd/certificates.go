@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	certificatesExpired            bool
+	certificatesExpiringWithinDays int
+)
+
+const (
+	certificatesCmdName = "certificates"
+
+	certificatesExpiredParamName      = "expired"
+	certificatesExpiredParamConfigKey = "pingone.services.platform.certificates.expired"
+
+	certificatesExpiringWithinDaysParamName      = "expiring-within-days"
+	certificatesExpiringWithinDaysParamConfigKey = "pingone.services.platform.certificates.expiring-within-days"
+
+	certificatesRulesConfigKey = "pingone.services.platform.certificates.rules"
+)
+
+var (
+	certificatesConfigurationParamMapping = map[string]string{
+		certificatesExpiredParamName:            certificatesExpiredParamConfigKey,
+		certificatesExpiringWithinDaysParamName: certificatesExpiringWithinDaysParamConfigKey,
+	}
+)
+
+var cleanCertificatesCmd = &cobra.Command{
+	Use:   certificatesCmdName,
+	Short: "Clean expired and expiring keys and certificates",
+	Long: fmt.Sprintf(`Clean away keys and certificates that have expired, or that expire within a number of days.  Run with --%s to report the validity of the matching items without deleting them.
+
+	Keys and certificates that are the environment default, or that are used by applications, are not deleted.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s 30 --%s
+	
+	`, dryRunParamName, certificatesCmdName, environmentIDParamName, certificatesExpiredParamName, dryRunParamName, certificatesCmdName, environmentIDParamName, certificatesExpiringWithinDaysParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		certificatesExpired := viper.GetBool(certificatesExpiredParamConfigKey)
+		certificatesExpiringWithinDays := viper.GetInt(certificatesExpiringWithinDaysParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for certificates.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf("Expired setting: %t", certificatesExpired)
+		l.Debug().Msgf("Expiring within days: %d", certificatesExpiringWithinDays)
+
+		var err error
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(certificatesRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := platform.CleanEnvironmentPlatformCertificatesConfig{
+			Environment:        environment,
+			Expired:            certificatesExpired,
+			ExpiringWithinDays: certificatesExpiringWithinDays,
+			Rules:              rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanCertificatesCmd.PersistentFlags().BoolVar(&certificatesExpired, certificatesExpiredParamName, false, "Delete keys and certificates that have expired.")
+	cleanCertificatesCmd.PersistentFlags().IntVar(&certificatesExpiringWithinDays, certificatesExpiringWithinDaysParamName, 0, "Delete keys and certificates that expire within this number of days.  Zero disables the check.")
+
+	if err := bindParams(certificatesConfigurationParamMapping, cleanCertificatesCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/credentials"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	credentialTypeNames        []string
+	credentialTypeNamePatterns []string
+)
+
+const (
+	credentialTypesCmdName = "credential-types"
+
+	credentialTypeNamesParamName      = "credential-type-name"
+	credentialTypeNamesParamConfigKey = "pingone.services.credentials.credential-types.names"
+
+	credentialTypeNamePatternsParamName      = "credential-type-name-pattern"
+	credentialTypeNamePatternsParamConfigKey = "pingone.services.credentials.credential-types.name-patterns"
+
+	credentialTypeRulesConfigKey = "pingone.services.credentials.credential-types.rules"
+)
+
+var (
+	credentialTypeConfigurationParamMapping = map[string]string{
+		credentialTypeNamesParamName:        credentialTypeNamesParamConfigKey,
+		credentialTypeNamePatternsParamName: credentialTypeNamePatternsParamConfigKey,
+	}
+)
+
+var cleanCredentialTypesCmd = &cobra.Command{
+	Use:   credentialTypesCmdName,
+	Short: "Clean unwanted sample and test Credentials credential types",
+	Long: fmt.Sprintf(`Clean away sample and test Credentials credential types, selected by name or name pattern.
+
+	The number of credentials issued through the affected credential issuance rules is reported before anything is deleted.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Test Credential" --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "^Sample .*" --%s
+	
+	`, credentialTypesCmdName, environmentIDParamName, credentialTypeNamesParamName, dryRunParamName, credentialTypesCmdName, environmentIDParamName, credentialTypeNamePatternsParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		credentialTypeNames := viper.GetStringSlice(credentialTypeNamesParamConfigKey)
+		credentialTypeNamePatterns := viper.GetStringSlice(credentialTypeNamePatternsParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for credential types.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Credential type names: "%s"`, strings.Join(credentialTypeNames, `", "`))
+		l.Debug().Msgf(`Credential type name patterns: "%s"`, strings.Join(credentialTypeNamePatterns, `", "`))
+
+		patterns, err := compilePatterns(credentialTypeNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(credentialTypeRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := credentials.CleanEnvironmentCredentialTypesConfig{
+			Environment:                     environment,
+			BootstrapCredentialTypeNames:    credentialTypeNames,
+			BootstrapCredentialTypePatterns: patterns,
+			Rules:                           rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanCredentialTypesCmd.PersistentFlags().StringSliceVar(&credentialTypeNames, credentialTypeNamesParamName, []string{}, "The list of credential type names to search for to delete.")
+	cleanCredentialTypesCmd.PersistentFlags().StringSliceVar(&credentialTypeNamePatterns, credentialTypeNamePatternsParamName, []string{}, "The list of regular expressions to match credential type names to delete.")
+
+	if err := bindParams(credentialTypeConfigurationParamMapping, cleanCredentialTypesCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
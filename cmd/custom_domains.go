@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	customDomainNamePatterns            []string
+	customDomainUnverifiedOlderThanDays int
+)
+
+const (
+	customDomainsCmdName = "custom-domains"
+
+	customDomainNamePatternsParamName      = "custom-domain-name-pattern"
+	customDomainNamePatternsParamConfigKey = "pingone.services.platform.custom-domains.name-patterns"
+
+	customDomainUnverifiedOlderThanDaysParamName      = "unverified-older-than-days"
+	customDomainUnverifiedOlderThanDaysParamConfigKey = "pingone.services.platform.custom-domains.unverified-older-than-days"
+
+	customDomainRulesConfigKey = "pingone.services.platform.custom-domains.rules"
+)
+
+var (
+	customDomainConfigurationParamMapping = map[string]string{
+		customDomainNamePatternsParamName:            customDomainNamePatternsParamConfigKey,
+		customDomainUnverifiedOlderThanDaysParamName: customDomainUnverifiedOlderThanDaysParamConfigKey,
+	}
+)
+
+var cleanCustomDomainsCmd = &cobra.Command{
+	Use:   customDomainsCmdName,
+	Short: "Clean unverified and test custom domains",
+	Long: fmt.Sprintf(`Clean away custom domains that have not been verified for longer than a number of days, or whose domain name matches a pattern.
+
+	A warning is given before removing the active custom domain that the environment currently uses.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s 7 --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "\.test\.example\.com$" --%s
+	
+	`, customDomainsCmdName, environmentIDParamName, customDomainUnverifiedOlderThanDaysParamName, dryRunParamName, customDomainsCmdName, environmentIDParamName, customDomainNamePatternsParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		customDomainNamePatterns := viper.GetStringSlice(customDomainNamePatternsParamConfigKey)
+		customDomainUnverifiedOlderThanDays := viper.GetInt(customDomainUnverifiedOlderThanDaysParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for custom domains.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Custom domain name patterns: "%s"`, strings.Join(customDomainNamePatterns, `", "`))
+		l.Debug().Msgf("Unverified older than days: %d", customDomainUnverifiedOlderThanDays)
+
+		patterns, err := compilePatterns(customDomainNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(customDomainRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := platform.CleanEnvironmentPlatformCustomDomainsConfig{
+			Environment:             environment,
+			BootstrapDomainPatterns: patterns,
+			UnverifiedOlderThanDays: customDomainUnverifiedOlderThanDays,
+			Rules:                   rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanCustomDomainsCmd.PersistentFlags().StringSliceVar(&customDomainNamePatterns, customDomainNamePatternsParamName, []string{}, "The list of regular expressions to match custom domain names to delete.")
+	cleanCustomDomainsCmd.PersistentFlags().IntVar(&customDomainUnverifiedOlderThanDays, customDomainUnverifiedOlderThanDaysParamName, 0, "Delete custom domains that still require verification and were created more than this number of days ago.  Zero disables the check.")
+
+	if err := bindParams(customDomainConfigurationParamMapping, cleanCustomDomainsCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
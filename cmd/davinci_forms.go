@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/clean/services/davinci"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/spf13/cobra"
@@ -20,6 +19,8 @@ const (
 
 	davinciFormNamesParamName      = "form-name"
 	davinciFormNamesParamConfigKey = "pingone.services.davinci.forms.names"
+
+	davinciFormRulesConfigKey = "pingone.services.davinci.forms.rules"
 )
 
 var (
@@ -55,13 +56,20 @@ var cleanDaVinciFormsCmd = &cobra.Command{
 			return err
 		}
 
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(davinciFormRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
 		cleanConfig := davinci.CleanEnvironmentDaVinciFormsConfig{
-			Environment: clean.CleanEnvironmentConfig{
-				EnvironmentID: viper.GetString(environmentIDParamConfigKey),
-				DryRun:        dryRun,
-				Client:        apiClient.API,
-			},
+			Environment:               environment,
 			BootstrapDaVinciFormNames: daVinciFormNames,
+			Rules:                     rules,
 		}
 
 		return cleanConfig.Clean(cmd.Context())
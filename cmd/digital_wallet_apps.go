@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/credentials"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	digitalWalletAppNames        []string
+	digitalWalletAppNamePatterns []string
+)
+
+const (
+	digitalWalletAppsCmdName = "digital-wallet-apps"
+
+	digitalWalletAppNamesParamName      = "digital-wallet-app-name"
+	digitalWalletAppNamesParamConfigKey = "pingone.services.credentials.digital-wallet-apps.names"
+
+	digitalWalletAppNamePatternsParamName      = "digital-wallet-app-name-pattern"
+	digitalWalletAppNamePatternsParamConfigKey = "pingone.services.credentials.digital-wallet-apps.name-patterns"
+
+	digitalWalletAppRulesConfigKey = "pingone.services.credentials.digital-wallet-apps.rules"
+)
+
+var (
+	digitalWalletAppConfigurationParamMapping = map[string]string{
+		digitalWalletAppNamesParamName:        digitalWalletAppNamesParamConfigKey,
+		digitalWalletAppNamePatternsParamName: digitalWalletAppNamePatternsParamConfigKey,
+	}
+)
+
+var cleanDigitalWalletAppsCmd = &cobra.Command{
+	Use:   digitalWalletAppsCmdName,
+	Short: "Clean unwanted sample and test Credentials digital wallet apps",
+	Long: fmt.Sprintf(`Clean away sample and test Credentials digital wallet application registrations, selected by name or name pattern.
+
+	The number of credentials issued through the affected credential issuance rules is reported before anything is deleted.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Test Wallet" --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "^Sample .*" --%s
+	
+	`, digitalWalletAppsCmdName, environmentIDParamName, digitalWalletAppNamesParamName, dryRunParamName, digitalWalletAppsCmdName, environmentIDParamName, digitalWalletAppNamePatternsParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		digitalWalletAppNames := viper.GetStringSlice(digitalWalletAppNamesParamConfigKey)
+		digitalWalletAppNamePatterns := viper.GetStringSlice(digitalWalletAppNamePatternsParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for digital wallet apps.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Digital wallet app names: "%s"`, strings.Join(digitalWalletAppNames, `", "`))
+		l.Debug().Msgf(`Digital wallet app name patterns: "%s"`, strings.Join(digitalWalletAppNamePatterns, `", "`))
+
+		patterns, err := compilePatterns(digitalWalletAppNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(digitalWalletAppRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := credentials.CleanEnvironmentDigitalWalletAppsConfig{
+			Environment:                       environment,
+			BootstrapDigitalWalletAppNames:    digitalWalletAppNames,
+			BootstrapDigitalWalletAppPatterns: patterns,
+			Rules:                             rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanDigitalWalletAppsCmd.PersistentFlags().StringSliceVar(&digitalWalletAppNames, digitalWalletAppNamesParamName, []string{}, "The list of digital wallet app names to search for to delete.")
+	cleanDigitalWalletAppsCmd.PersistentFlags().StringSliceVar(&digitalWalletAppNamePatterns, digitalWalletAppNamePatternsParamName, []string{}, "The list of regular expressions to match digital wallet app names to delete.")
+
+	if err := bindParams(digitalWalletAppConfigurationParamMapping, cleanDigitalWalletAppsCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/spf13/cobra"
@@ -20,6 +19,8 @@ const (
 
 	directoryAttributeNamesParamName      = "attribute-names"
 	directoryAttributeNamesParamConfigKey = "pingone.services.platform.directory-schema.attribute-names"
+
+	directoryRulesConfigKey = "pingone.services.platform.directory-schema.rules"
 )
 
 var (
@@ -56,14 +57,21 @@ var cleanDirectoryAttributesCmd = &cobra.Command{
 			return err
 		}
 
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(directoryRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
 		cleanConfig := platform.CleanEnvironmentPlatformDirectoryAttributeConfig{
-			Environment: clean.CleanEnvironmentConfig{
-				EnvironmentID: viper.GetString(environmentIDParamConfigKey),
-				DryRun:        dryRun,
-				Client:        apiClient.API,
-			},
+			Environment:             environment,
 			BootstrapAttributeNames: directoryAttributeNames,
 			SchemaName:              nil,
+			Rules:                   rules,
 		}
 
 		return cleanConfig.Clean(cmd.Context())
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	gatewayNames           []string
+	gatewayNamePatterns    []string
+	gatewayNoHeartbeatDays int
+)
+
+const (
+	gatewaysCmdName = "gateways"
+
+	gatewayNamesParamName      = "gateway-name"
+	gatewayNamesParamConfigKey = "pingone.services.platform.gateways.names"
+
+	gatewayNamePatternsParamName      = "gateway-name-pattern"
+	gatewayNamePatternsParamConfigKey = "pingone.services.platform.gateways.name-patterns"
+
+	gatewayNoHeartbeatDaysParamName      = "no-heartbeat-days"
+	gatewayNoHeartbeatDaysParamConfigKey = "pingone.services.platform.gateways.no-heartbeat-days"
+
+	gatewayRulesConfigKey = "pingone.services.platform.gateways.rules"
+)
+
+var (
+	gatewayConfigurationParamMapping = map[string]string{
+		gatewayNamesParamName:           gatewayNamesParamConfigKey,
+		gatewayNamePatternsParamName:    gatewayNamePatternsParamConfigKey,
+		gatewayNoHeartbeatDaysParamName: gatewayNoHeartbeatDaysParamConfigKey,
+	}
+)
+
+var cleanGatewaysCmd = &cobra.Command{
+	Use:   gatewaysCmdName,
+	Short: "Clean unwanted demo and test gateways",
+	Long: fmt.Sprintf(`Clean away demo and test LDAP, Kerberos, RADIUS and API gateways, selected by name, name pattern or the time since an instance of the gateway last reported a heartbeat.
+
+	The credentials of each gateway are revoked before the gateway is deleted.  The gateway instances that are connected when the gateway is deleted are listed, as they will stop working.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Test LDAP Gateway" --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s 30 --%s
+	
+	`, gatewaysCmdName, environmentIDParamName, gatewayNamesParamName, dryRunParamName, gatewaysCmdName, environmentIDParamName, gatewayNoHeartbeatDaysParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		gatewayNames := viper.GetStringSlice(gatewayNamesParamConfigKey)
+		gatewayNamePatterns := viper.GetStringSlice(gatewayNamePatternsParamConfigKey)
+		gatewayNoHeartbeatDays := viper.GetInt(gatewayNoHeartbeatDaysParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for gateways.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Gateway names: "%s"`, strings.Join(gatewayNames, `", "`))
+		l.Debug().Msgf(`Gateway name patterns: "%s"`, strings.Join(gatewayNamePatterns, `", "`))
+		l.Debug().Msgf("No heartbeat days: %d", gatewayNoHeartbeatDays)
+
+		patterns, err := compilePatterns(gatewayNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(gatewayRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := platform.CleanEnvironmentPlatformGatewaysConfig{
+			Environment:              environment,
+			BootstrapGatewayNames:    gatewayNames,
+			BootstrapGatewayPatterns: patterns,
+			NoHeartbeatDays:          gatewayNoHeartbeatDays,
+			Rules:                    rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanGatewaysCmd.PersistentFlags().StringSliceVar(&gatewayNames, gatewayNamesParamName, []string{}, "The list of gateway names to search for to delete.")
+	cleanGatewaysCmd.PersistentFlags().StringSliceVar(&gatewayNamePatterns, gatewayNamePatternsParamName, []string{}, "The list of regular expressions to match gateway names to delete.")
+	cleanGatewaysCmd.PersistentFlags().IntVar(&gatewayNoHeartbeatDays, gatewayNoHeartbeatDaysParamName, 0, "Delete gateways where no instance has reported a heartbeat for more than this number of days.  Gateways that no instance has connected to are included once they were created more than this number of days ago.  Zero disables the check.")
+
+	if err := bindParams(gatewayConfigurationParamMapping, cleanGatewaysCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
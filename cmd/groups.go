@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/sso"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	groupNames              []string
+	groupNamePatterns       []string
+	groupEmptyOlderThanDays int
+)
+
+const (
+	groupsCmdName = "groups"
+
+	groupNamesParamName      = "group-name"
+	groupNamesParamConfigKey = "pingone.services.sso.groups.names"
+
+	groupNamePatternsParamName      = "group-name-pattern"
+	groupNamePatternsParamConfigKey = "pingone.services.sso.groups.name-patterns"
+
+	groupEmptyOlderThanDaysParamName      = "empty-older-than-days"
+	groupEmptyOlderThanDaysParamConfigKey = "pingone.services.sso.groups.empty-older-than-days"
+
+	groupRulesConfigKey = "pingone.services.sso.groups.rules"
+)
+
+var (
+	groupConfigurationParamMapping = map[string]string{
+		groupNamesParamName:              groupNamesParamConfigKey,
+		groupNamePatternsParamName:       groupNamePatternsParamConfigKey,
+		groupEmptyOlderThanDaysParamName: groupEmptyOlderThanDaysParamConfigKey,
+	}
+)
+
+var cleanGroupsCmd = &cobra.Command{
+	Use:   groupsCmdName,
+	Short: "Clean unwanted demo, test and empty groups",
+	Long: fmt.Sprintf(`Clean away demo and test groups, selected by name, name pattern or because they are empty and were
+	created more than a number of days ago.  PingOne doesn't record when a group's members last changed, so a group that was recently emptied is
+	selected as soon as it is old enough.
+
+	Groups that have admin role assignments are reported as blocked and are not deleted.  Nested groups of a deleted group are kept.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Sample Group" --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "^tf-acc-" --%s 30 --%s
+	
+	`, groupsCmdName, environmentIDParamName, groupNamesParamName, dryRunParamName, groupsCmdName, environmentIDParamName, groupNamePatternsParamName, groupEmptyOlderThanDaysParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		groupNames := viper.GetStringSlice(groupNamesParamConfigKey)
+		groupNamePatterns := viper.GetStringSlice(groupNamePatternsParamConfigKey)
+		groupEmptyOlderThanDays := viper.GetInt(groupEmptyOlderThanDaysParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for groups.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Group names: "%s"`, strings.Join(groupNames, `", "`))
+		l.Debug().Msgf(`Group name patterns: "%s"`, strings.Join(groupNamePatterns, `", "`))
+		l.Debug().Msgf("Empty older than days: %d", groupEmptyOlderThanDays)
+
+		patterns, err := compilePatterns(groupNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(groupRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := sso.CleanEnvironmentGroupsConfig{
+			Environment:            environment,
+			BootstrapGroupNames:    groupNames,
+			BootstrapGroupPatterns: patterns,
+			EmptyOlderThanDays:     groupEmptyOlderThanDays,
+			Rules:                  rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanGroupsCmd.PersistentFlags().StringSliceVar(&groupNames, groupNamesParamName, []string{}, "The list of group names to search for to delete.")
+	cleanGroupsCmd.PersistentFlags().StringSliceVar(&groupNamePatterns, groupNamePatternsParamName, []string{}, "The list of regular expressions to match group names to delete.")
+	cleanGroupsCmd.PersistentFlags().IntVar(&groupEmptyOlderThanDays, groupEmptyOlderThanDaysParamName, 0, "Select groups that have no member users or nested groups and were created more than this number of days ago.  Disabled by default.")
+
+	if err := bindParams(groupConfigurationParamMapping, cleanGroupsCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/patrickcping/pingone-sweep/internal/harden"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	hardenBaselineFile string
+	hardenEnforce      bool
+)
+
+const (
+	hardenCmdName = "harden"
+
+	hardenBaselineFileParamName      = "baseline-file"
+	hardenBaselineFileParamConfigKey = "harden.baseline-file"
+
+	hardenEnforceParamName      = "enforce"
+	hardenEnforceParamConfigKey = "harden.enforce"
+)
+
+var (
+	hardenConfigurationParamMapping = map[string]string{
+		hardenBaselineFileParamName: hardenBaselineFileParamConfigKey,
+		hardenEnforceParamName:      hardenEnforceParamConfigKey,
+	}
+)
+
+var hardenCmd = &cobra.Command{
+	Use:   hardenCmdName,
+	Short: "Check an environment against a security hardening baseline",
+	Long: fmt.Sprintf(`Check an environment against a security hardening baseline and, optionally, enforce it.
+
+	The baseline covers password policy lockout and history, MFA device policy methods and OTP failures, application refresh token lifetimes and grant types, and the notification sender.  PingOne system applications, the worker application running the sweep and protected applications are not checked.  Each finding has a severity.  Without a baseline file, the default baseline is used; a baseline file only needs to contain the values that differ from the default.
+
+	Findings marked as manual cannot be corrected automatically.  Enforcement is skipped in a dry run.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s ./baseline.yml --%s --%s=false
+	
+	`, hardenCmdName, environmentIDParamName, hardenCmdName, environmentIDParamName, hardenBaselineFileParamName, hardenEnforceParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		hardenBaselineFile := viper.GetString(hardenBaselineFileParamConfigKey)
+		hardenEnforce := viper.GetBool(hardenEnforceParamConfigKey)
+
+		l.Debug().Msgf("Harden Command called.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Baseline file: "%s"`, hardenBaselineFile)
+		l.Debug().Msgf("Enforce setting: %t", hardenEnforce)
+
+		baseline := harden.DefaultBaseline()
+		if hardenBaselineFile != "" {
+			var err error
+			baseline, err = harden.ReadBaselineFile(hardenBaselineFile)
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		hardenConfig := harden.HardenEnvironmentConfig{
+			Environment:    environment,
+			Baseline:       baseline,
+			Enforce:        hardenEnforce,
+			WorkerClientID: viper.GetString(workerClientIDParamConfigKey),
+		}
+
+		_, err = hardenConfig.Harden(cmd.Context())
+		return err
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	hardenCmd.PersistentFlags().StringVar(&hardenBaselineFile, hardenBaselineFileParamName, "", "The path to a YAML file of the hardening baseline.  The default baseline is used if not set.")
+	hardenCmd.PersistentFlags().BoolVar(&hardenEnforce, hardenEnforceParamName, false, "Correct the findings that can be corrected automatically.")
+
+	if err := bindParams(hardenConfigurationParamMapping, hardenCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
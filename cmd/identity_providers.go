@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/sso"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	identityProviderNames        []string
+	identityProviderNamePatterns []string
+	identityProviderTypes        []string
+	identityProviderDisabled     bool
+)
+
+const (
+	identityProvidersCmdName = "identity-providers"
+
+	identityProviderNamesParamName      = "identity-provider-name"
+	identityProviderNamesParamConfigKey = "pingone.services.sso.identity-providers.names"
+
+	identityProviderNamePatternsParamName      = "identity-provider-name-pattern"
+	identityProviderNamePatternsParamConfigKey = "pingone.services.sso.identity-providers.name-patterns"
+
+	identityProviderTypesParamName      = "identity-provider-type"
+	identityProviderTypesParamConfigKey = "pingone.services.sso.identity-providers.types"
+
+	identityProviderDisabledParamName      = "disabled"
+	identityProviderDisabledParamConfigKey = "pingone.services.sso.identity-providers.disabled"
+
+	identityProviderRulesConfigKey = "pingone.services.sso.identity-providers.rules"
+)
+
+var (
+	identityProviderConfigurationParamMapping = map[string]string{
+		identityProviderNamesParamName:        identityProviderNamesParamConfigKey,
+		identityProviderNamePatternsParamName: identityProviderNamePatternsParamConfigKey,
+		identityProviderTypesParamName:        identityProviderTypesParamConfigKey,
+		identityProviderDisabledParamName:     identityProviderDisabledParamConfigKey,
+	}
+)
+
+var cleanIdentityProvidersCmd = &cobra.Command{
+	Use:   identityProvidersCmdName,
+	Short: "Clean unwanted demo and test external identity providers",
+	Long: fmt.Sprintf(`Clean away demo and test external identity providers, selected by name, name pattern, type or disabled state.
+
+	Identity providers used by the login, identifier first or identity provider actions of a sign-on policy are reported as blocked and are not deleted.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Test SAML IdP" --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s FACEBOOK --%s GOOGLE --%s --%s
+	
+	`, identityProvidersCmdName, environmentIDParamName, identityProviderNamesParamName, dryRunParamName, identityProvidersCmdName, environmentIDParamName, identityProviderTypesParamName, identityProviderTypesParamName, identityProviderDisabledParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		identityProviderNames := viper.GetStringSlice(identityProviderNamesParamConfigKey)
+		identityProviderNamePatterns := viper.GetStringSlice(identityProviderNamePatternsParamConfigKey)
+		identityProviderTypes := viper.GetStringSlice(identityProviderTypesParamConfigKey)
+		identityProviderDisabled := viper.GetBool(identityProviderDisabledParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for identity providers.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Identity provider names: "%s"`, strings.Join(identityProviderNames, `", "`))
+		l.Debug().Msgf(`Identity provider name patterns: "%s"`, strings.Join(identityProviderNamePatterns, `", "`))
+		l.Debug().Msgf(`Identity provider types: "%s"`, strings.Join(identityProviderTypes, `", "`))
+		l.Debug().Msgf("Disabled setting: %t", identityProviderDisabled)
+
+		patterns, err := compilePatterns(identityProviderNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		types := make([]management.EnumIdentityProviderExt, 0, len(identityProviderTypes))
+		for _, identityProviderType := range identityProviderTypes {
+			t, err := management.NewEnumIdentityProviderExtFromValue(strings.ToUpper(identityProviderType))
+			if err != nil {
+				return err
+			}
+			types = append(types, *t)
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(identityProviderRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := sso.CleanEnvironmentIdentityProvidersConfig{
+			Environment:                       environment,
+			BootstrapIdentityProviderNames:    identityProviderNames,
+			BootstrapIdentityProviderPatterns: patterns,
+			IdentityProviderTypes:             types,
+			Disabled:                          identityProviderDisabled,
+			Rules:                             rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanIdentityProvidersCmd.PersistentFlags().StringSliceVar(&identityProviderNames, identityProviderNamesParamName, []string{}, "The list of identity provider names to search for to delete.")
+	cleanIdentityProvidersCmd.PersistentFlags().StringSliceVar(&identityProviderNamePatterns, identityProviderNamePatternsParamName, []string{}, "The list of regular expressions to match identity provider names to delete.")
+	cleanIdentityProvidersCmd.PersistentFlags().StringSliceVar(&identityProviderTypes, identityProviderTypesParamName, []string{}, "The list of identity provider types to delete (for example FACEBOOK, GOOGLE, OPENID_CONNECT, SAML).")
+	cleanIdentityProvidersCmd.PersistentFlags().BoolVar(&identityProviderDisabled, identityProviderDisabledParamName, false, "Delete identity providers that are disabled.")
+
+	if err := bindParams(identityProviderConfigurationParamMapping, cleanIdentityProvidersCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
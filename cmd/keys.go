@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/spf13/cobra"
@@ -24,6 +23,8 @@ const (
 
 	keysCaseSensitiveParamName      = "case-sensitive"
 	keysCaseSensitiveParamConfigKey = "pingone.services.platform.keys.case-sensitive"
+
+	keysRulesConfigKey = "pingone.services.platform.keys.rules"
 )
 
 var (
@@ -61,14 +62,21 @@ var cleanKeysCmd = &cobra.Command{
 			return err
 		}
 
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(keysRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
 		cleanConfig := platform.CleanEnvironmentPlatformKeysConfig{
-			Environment: clean.CleanEnvironmentConfig{
-				EnvironmentID: viper.GetString(environmentIDParamConfigKey),
-				DryRun:        dryRun,
-				Client:        apiClient.API,
-			},
+			Environment:               environment,
 			BootstrapIssuerDNPrefixes: keyIssuerDNPrefixes,
 			CaseSensitive:             keyCaseSensitive,
+			Rules:                     rules,
 		}
 
 		return cleanConfig.Clean(cmd.Context())
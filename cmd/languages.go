@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	languageEnabledLocales []string
+)
+
+const (
+	languagesCmdName = "languages"
+
+	languageEnabledLocalesParamName      = "enabled-locale"
+	languageEnabledLocalesParamConfigKey = "pingone.services.platform.languages.enabled-locales"
+)
+
+var (
+	languageConfigurationParamMapping = map[string]string{
+		languageEnabledLocalesParamName: languageEnabledLocalesParamConfigKey,
+	}
+)
+
+var cleanLanguagesCmd = &cobra.Command{
+	Use:   languagesCmdName,
+	Short: "Reset the enabled languages to a desired list of locales",
+	Long: fmt.Sprintf(`Reset the languages of an environment to a desired list of enabled locales.
+
+	Custom languages that are not in the list are deleted, and other enabled languages that are not in the list are disabled.  The environment's default language is kept.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s en --%s fr --%s
+	
+	`, languagesCmdName, environmentIDParamName, languageEnabledLocalesParamName, languageEnabledLocalesParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		languageEnabledLocales := viper.GetStringSlice(languageEnabledLocalesParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for languages.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Enabled locales: "%s"`, strings.Join(languageEnabledLocales, `", "`))
+
+		var err error
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := platform.CleanEnvironmentPlatformLanguagesConfig{
+			Environment:    environment,
+			DesiredLocales: languageEnabledLocales,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanLanguagesCmd.PersistentFlags().StringSliceVar(&languageEnabledLocales, languageEnabledLocalesParamName, []string{}, "The list of locales (for example en, fr, de) that should remain enabled.  Other languages are disabled, or deleted if they are custom languages.")
+
+	if err := bindParams(languageConfigurationParamMapping, cleanLanguagesCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/clean/services/mfa"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/spf13/cobra"
@@ -12,7 +11,8 @@ import (
 )
 
 var (
-	mfaDevicePolicyNames []string
+	mfaDevicePolicyNames        []string
+	mfaDevicePolicyFingerprints map[string]string
 )
 
 const (
@@ -20,11 +20,17 @@ const (
 
 	mfaDevicePolicyNamesParamName      = "policy-name"
 	mfaDevicePolicyNamesParamConfigKey = "pingone.services.mfa.device-policies.names"
+
+	mfaDevicePolicyFingerprintsParamName      = "bootstrap-fingerprint"
+	mfaDevicePolicyFingerprintsParamConfigKey = "pingone.services.mfa.device-policies.fingerprints"
+
+	mfaDevicePolicyRulesConfigKey = "pingone.services.mfa.device-policies.rules"
 )
 
 var (
 	mfaDevicePolicyConfigurationParamMapping = map[string]string{
-		mfaDevicePolicyNamesParamName: mfaDevicePolicyNamesParamConfigKey,
+		mfaDevicePolicyNamesParamName:        mfaDevicePolicyNamesParamConfigKey,
+		mfaDevicePolicyFingerprintsParamName: mfaDevicePolicyFingerprintsParamConfigKey,
 	}
 )
 
@@ -44,6 +50,7 @@ var cleanMfaDevicePoliciesCmd = &cobra.Command{
 
 		dryRun := viper.GetBool(dryRunParamConfigKey)
 		mfaDevicePolicyNames := viper.GetStringSlice(mfaDevicePolicyNamesParamConfigKey)
+		mfaDevicePolicyFingerprints := viper.GetStringMapString(mfaDevicePolicyFingerprintsParamConfigKey)
 
 		l.Debug().Msgf("Clean Command called for MFA Device policies.")
 		l.Debug().Msgf("Dry run setting: %t", dryRun)
@@ -55,13 +62,21 @@ var cleanMfaDevicePoliciesCmd = &cobra.Command{
 			return err
 		}
 
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(mfaDevicePolicyRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
 		cleanConfig := mfa.CleanEnvironmentPlatformMFADevicePoliciesConfig{
-			Environment: clean.CleanEnvironmentConfig{
-				EnvironmentID: viper.GetString(environmentIDParamConfigKey),
-				DryRun:        dryRun,
-				Client:        apiClient.API,
-			},
-			BootstrapMFADevicePolicyNames: mfaDevicePolicyNames,
+			Environment:                          environment,
+			BootstrapMFADevicePolicyNames:        mfaDevicePolicyNames,
+			BootstrapMFADevicePolicyFingerprints: mfaDevicePolicyFingerprints,
+			Rules:                                rules,
 		}
 
 		return cleanConfig.Clean(cmd.Context())
@@ -72,6 +87,7 @@ func init() {
 	l := logger.Get()
 
 	cleanMfaDevicePoliciesCmd.PersistentFlags().StringSliceVar(&mfaDevicePolicyNames, mfaDevicePolicyNamesParamName, mfa.BootstrapMFADevicePolicyNames, "The list of MFA Device policy names to search for to delete.  Case sensitive.")
+	cleanMfaDevicePoliciesCmd.PersistentFlags().StringToStringVar(&mfaDevicePolicyFingerprints, mfaDevicePolicyFingerprintsParamName, mfa.BootstrapMFADevicePolicyFingerprints, "The fingerprints of the pristine bootstrap MFA device policy configuration, in the format name=fingerprint.  Items with a known fingerprint are classified as pristine, modified or renamed-pristine.")
 
 	if err := bindParams(mfaDevicePolicyConfigurationParamMapping, cleanMfaDevicePoliciesCmd); err != nil {
 		l.Err(err).Msgf("Error binding parameters: %s", err)
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/clean/services/mfa"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/spf13/cobra"
@@ -20,6 +19,8 @@ const (
 
 	mfaFido2PolicyNamesParamName      = "policy-name"
 	mfaFido2PolicyNamesParamConfigKey = "pingone.services.mfa.fido2-policies.names"
+
+	mfaFido2PolicyRulesConfigKey = "pingone.services.mfa.fido2-policies.rules"
 )
 
 var (
@@ -55,13 +56,20 @@ var cleanMfaFido2PoliciesCmd = &cobra.Command{
 			return err
 		}
 
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(mfaFido2PolicyRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
 		cleanConfig := mfa.CleanEnvironmentPlatformMFAFIDO2PoliciesConfig{
-			Environment: clean.CleanEnvironmentConfig{
-				EnvironmentID: viper.GetString(environmentIDParamConfigKey),
-				DryRun:        dryRun,
-				Client:        apiClient.API,
-			},
+			Environment:                  environment,
 			BootstrapMFAFIDO2PolicyNames: mfaFido2PolicyNames,
+			Rules:                        rules,
 		}
 
 		return cleanConfig.Clean(cmd.Context())
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/spf13/cobra"
@@ -20,6 +19,8 @@ const (
 
 	notificationPolicyNamesParamName      = "policy-name"
 	notificationPolicyNamesParamConfigKey = "pingone.services.platform.notification-policies.names"
+
+	notificationPolicyRulesConfigKey = "pingone.services.platform.notification-policies.rules"
 )
 
 var (
@@ -55,13 +56,20 @@ var cleanNotificationPoliciesCmd = &cobra.Command{
 			return err
 		}
 
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(notificationPolicyRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
 		cleanConfig := platform.CleanEnvironmentPlatformNotificationPoliciesConfig{
-			Environment: clean.CleanEnvironmentConfig{
-				EnvironmentID: viper.GetString(environmentIDParamConfigKey),
-				DryRun:        dryRun,
-				Client:        apiClient.API,
-			},
+			Environment:                      environment,
 			BootstrapNotificationPolicyNames: notificationPolicyNames,
+			Rules:                            rules,
 		}
 
 		return cleanConfig.Clean(cmd.Context())
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	notificationTemplateNames   []string
+	notificationTemplateLocales []string
+)
+
+const (
+	notificationTemplatesCmdName = "notification-templates"
+
+	notificationTemplateNamesParamName      = "template-name"
+	notificationTemplateNamesParamConfigKey = "pingone.services.platform.notification-templates.templates"
+
+	notificationTemplateLocalesParamName      = "locale"
+	notificationTemplateLocalesParamConfigKey = "pingone.services.platform.notification-templates.locales"
+
+	notificationTemplateRulesConfigKey = "pingone.services.platform.notification-templates.rules"
+)
+
+var (
+	notificationTemplateConfigurationParamMapping = map[string]string{
+		notificationTemplateNamesParamName:   notificationTemplateNamesParamConfigKey,
+		notificationTemplateLocalesParamName: notificationTemplateLocalesParamConfigKey,
+	}
+)
+
+var cleanNotificationTemplatesCmd = &cobra.Command{
+	Use:   notificationTemplatesCmdName,
+	Short: "Reset customised notification template contents to the PingOne defaults",
+	Long: fmt.Sprintf(`Clean away customised notification template contents, such as demo text, so that the PingOne default contents are used.
+
+	Customised contents of the selected templates (optionally limited to the selected locales) are deleted.  PingOne default contents are never modified.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s general --%s verification_code_template --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s email_verification_user --%s en --%s fr --%s
+	
+	`, notificationTemplatesCmdName, environmentIDParamName, notificationTemplateNamesParamName, notificationTemplateNamesParamName, dryRunParamName, notificationTemplatesCmdName, environmentIDParamName, notificationTemplateNamesParamName, notificationTemplateLocalesParamName, notificationTemplateLocalesParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		notificationTemplateNames := viper.GetStringSlice(notificationTemplateNamesParamConfigKey)
+		notificationTemplateLocales := viper.GetStringSlice(notificationTemplateLocalesParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for notification templates.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Template names: "%s"`, strings.Join(notificationTemplateNames, `", "`))
+		l.Debug().Msgf(`Locales: "%s"`, strings.Join(notificationTemplateLocales, `", "`))
+
+		var err error
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(notificationTemplateRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := platform.CleanEnvironmentPlatformNotificationTemplatesConfig{
+			Environment:   environment,
+			TemplateNames: notificationTemplateNames,
+			Locales:       notificationTemplateLocales,
+			Rules:         rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanNotificationTemplatesCmd.PersistentFlags().StringSliceVar(&notificationTemplateNames, notificationTemplateNamesParamName, []string{}, "The list of template names whose customised contents are deleted (for example general, verification_code_template, email_verification_user).")
+	cleanNotificationTemplatesCmd.PersistentFlags().StringSliceVar(&notificationTemplateLocales, notificationTemplateLocalesParamName, []string{}, "The list of locales whose customised contents are deleted.  By default, all locales are included.")
+
+	if err := bindParams(notificationTemplateConfigurationParamMapping, cleanNotificationTemplatesCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
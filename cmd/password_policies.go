@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/clean/services/sso"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/spf13/cobra"
@@ -12,7 +11,8 @@ import (
 )
 
 var (
-	passwordPolicyNames []string
+	passwordPolicyNames        []string
+	passwordPolicyFingerprints map[string]string
 )
 
 const (
@@ -20,11 +20,17 @@ const (
 
 	passwordPolicyNamesParamName      = "policy-name"
 	passwordPolicyNamesParamConfigKey = "pingone.services.sso.password-policies.names"
+
+	passwordPolicyFingerprintsParamName      = "bootstrap-fingerprint"
+	passwordPolicyFingerprintsParamConfigKey = "pingone.services.sso.password-policies.fingerprints"
+
+	passwordPolicyRulesConfigKey = "pingone.services.sso.password-policies.rules"
 )
 
 var (
 	passwordPolicyConfigurationParamMapping = map[string]string{
-		passwordPolicyNamesParamName: passwordPolicyNamesParamConfigKey,
+		passwordPolicyNamesParamName:        passwordPolicyNamesParamConfigKey,
+		passwordPolicyFingerprintsParamName: passwordPolicyFingerprintsParamConfigKey,
 	}
 )
 
@@ -44,6 +50,7 @@ var cleanPasswordPoliciesCmd = &cobra.Command{
 
 		dryRun := viper.GetBool(dryRunParamConfigKey)
 		passwordPolicyNames := viper.GetStringSlice(passwordPolicyNamesParamConfigKey)
+		passwordPolicyFingerprints := viper.GetStringMapString(passwordPolicyFingerprintsParamConfigKey)
 
 		l.Debug().Msgf("Clean Command called for password policies.")
 		l.Debug().Msgf("Dry run setting: %t", dryRun)
@@ -55,13 +62,21 @@ var cleanPasswordPoliciesCmd = &cobra.Command{
 			return err
 		}
 
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(passwordPolicyRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
 		cleanConfig := sso.CleanEnvironmentPlatformPasswordPoliciesConfig{
-			Environment: clean.CleanEnvironmentConfig{
-				EnvironmentID: viper.GetString(environmentIDParamConfigKey),
-				DryRun:        dryRun,
-				Client:        apiClient.API,
-			},
-			BootstrapPasswordPolicyNames: passwordPolicyNames,
+			Environment:                         environment,
+			BootstrapPasswordPolicyNames:        passwordPolicyNames,
+			BootstrapPasswordPolicyFingerprints: passwordPolicyFingerprints,
+			Rules:                               rules,
 		}
 
 		return cleanConfig.Clean(cmd.Context())
@@ -72,6 +87,7 @@ func init() {
 	l := logger.Get()
 
 	cleanPasswordPoliciesCmd.PersistentFlags().StringSliceVar(&passwordPolicyNames, passwordPolicyNamesParamName, sso.BootstrapPasswordPolicyNames, "The list of password policy names to search for to delete.  Case sensitive.")
+	cleanPasswordPoliciesCmd.PersistentFlags().StringToStringVar(&passwordPolicyFingerprints, passwordPolicyFingerprintsParamName, sso.BootstrapPasswordPolicyFingerprints, "The fingerprints of the pristine bootstrap password policy configuration, in the format name=fingerprint.  Items with a known fingerprint are classified as pristine, modified or renamed-pristine.")
 
 	if err := bindParams(passwordPolicyConfigurationParamMapping, cleanPasswordPoliciesCmd); err != nil {
 		l.Err(err).Msgf("Error binding parameters: %s", err)
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	phoneDeliverySettingsCmdName = "phone-delivery-settings"
+
+	phoneDeliverySettingsDesiredStateName = "phone-delivery-settings"
+)
+
+var cleanPhoneDeliverySettingsCmd = &cobra.Command{
+	Use:   phoneDeliverySettingsCmdName,
+	Short: "Reset changed phone delivery settings to a desired state",
+	Long: fmt.Sprintf(`Reset the environment's phone delivery settings (such as the SMS and voice provider fallback chain, held in the notification settings) to the "%s" section of the desired state file, where they differ.
+
+	Only the settings in the desired state file are compared and changed.  A dry run shows the difference for each setting.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s ./desired-state.yml --%s
+	
+	`, phoneDeliverySettingsDesiredStateName, phoneDeliverySettingsCmdName, environmentIDParamName, desiredStateFileParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for phone delivery settings.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+
+		desiredState, err := readDesiredState(phoneDeliverySettingsDesiredStateName)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := platform.CleanEnvironmentPlatformPhoneDeliverySettingsConfig{
+			Environment:  environment,
+			DesiredState: desiredState,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/sso"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	populationNames           []string
+	populationTargetName      string
+	populationUserConcurrency int
+	populationDeleteUsers     bool
+)
+
+const (
+	populationsCmdName = "populations"
+
+	populationNamesParamName      = "population-name"
+	populationNamesParamConfigKey = "pingone.services.sso.populations.names"
+
+	populationTargetNameParamName      = "move-users-to-population"
+	populationTargetNameParamConfigKey = "pingone.services.sso.populations.move-users-to-population"
+
+	populationUserConcurrencyParamName      = "user-concurrency"
+	populationUserConcurrencyParamConfigKey = "pingone.services.sso.populations.user-concurrency"
+
+	populationDeleteUsersParamName      = "delete-users"
+	populationDeleteUsersParamConfigKey = "pingone.services.sso.populations.delete-users"
+
+	populationRulesConfigKey = "pingone.services.sso.populations.rules"
+)
+
+var (
+	populationConfigurationParamMapping = map[string]string{
+		populationNamesParamName:           populationNamesParamConfigKey,
+		populationTargetNameParamName:      populationTargetNameParamConfigKey,
+		populationUserConcurrencyParamName: populationUserConcurrencyParamConfigKey,
+		populationDeleteUsersParamName:     populationDeleteUsersParamConfigKey,
+	}
+)
+
+var cleanPopulationsCmd = &cobra.Command{
+	Use:   populationsCmdName,
+	Short: "Clean unwanted demo populations and their users",
+	Long: fmt.Sprintf(`Clean away demo configuration and prepare an environment for production-ready configuration.
+
+	A population that still has users is only deleted when the --%s parameter is set, in which case the users are moved to that population first,
+	or when the --%s parameter is set, in which case the users are deleted first.  Populations with protected users are not deleted with their users.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Sample Users" --%s "Customers" --%s
+	
+	`, populationTargetNameParamName, populationDeleteUsersParamName, populationsCmdName, environmentIDParamName, dryRunParamName, populationsCmdName, environmentIDParamName, populationDeleteUsersParamName, dryRunParamName, populationsCmdName, environmentIDParamName, populationNamesParamName, populationTargetNameParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		populationNames := viper.GetStringSlice(populationNamesParamConfigKey)
+		populationTargetName := viper.GetString(populationTargetNameParamConfigKey)
+		populationUserConcurrency := viper.GetInt(populationUserConcurrencyParamConfigKey)
+		populationDeleteUsers := viper.GetBool(populationDeleteUsersParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for populations.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Population names: "%s"`, strings.Join(populationNames, `", "`))
+		l.Debug().Msgf(`Move users to population: "%s"`, populationTargetName)
+		l.Debug().Msgf("User concurrency: %d", populationUserConcurrency)
+		l.Debug().Msgf("Delete users: %t", populationDeleteUsers)
+
+		var err error
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(populationRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := sso.CleanEnvironmentPopulationsConfig{
+			Environment:              environment,
+			BootstrapPopulationNames: populationNames,
+			TargetPopulationName:     populationTargetName,
+			UserConcurrency:          populationUserConcurrency,
+			Rules:                    rules,
+			DeleteUsers:              populationDeleteUsers,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanPopulationsCmd.PersistentFlags().StringSliceVar(&populationNames, populationNamesParamName, sso.BootstrapPopulationNames, "The list of population names to search for to delete.")
+	cleanPopulationsCmd.PersistentFlags().StringVar(&populationTargetName, populationTargetNameParamName, "", "The name of a population to move users to before their population is deleted.")
+	cleanPopulationsCmd.PersistentFlags().BoolVar(&populationDeleteUsers, populationDeleteUsersParamName, false, "Delete the users of a population before the population is deleted, where they are not moved to another population.  By default, populations that still have users are not deleted.")
+	cleanPopulationsCmd.PersistentFlags().IntVar(&populationUserConcurrency, populationUserConcurrencyParamName, sso.DefaultUserConcurrency, "The number of users to delete or move at the same time.")
+
+	if err := bindParams(populationConfigurationParamMapping, cleanPopulationsCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
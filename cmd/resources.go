@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/sso"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	resourceNames             []string
+	resourceNamePatterns      []string
+	resourceScopeNames        []string
+	resourceScopeNamePatterns []string
+	resourceOrphaned          bool
+)
+
+const (
+	resourcesCmdName = "resources"
+
+	resourceNamesParamName      = "resource-name"
+	resourceNamesParamConfigKey = "pingone.services.sso.resources.names"
+
+	resourceNamePatternsParamName      = "resource-name-pattern"
+	resourceNamePatternsParamConfigKey = "pingone.services.sso.resources.name-patterns"
+
+	resourceScopeNamesParamName      = "scope-name"
+	resourceScopeNamesParamConfigKey = "pingone.services.sso.resources.scope-names"
+
+	resourceScopeNamePatternsParamName      = "scope-name-pattern"
+	resourceScopeNamePatternsParamConfigKey = "pingone.services.sso.resources.scope-name-patterns"
+
+	resourceOrphanedParamName      = "orphaned"
+	resourceOrphanedParamConfigKey = "pingone.services.sso.resources.orphaned"
+
+	resourceRulesConfigKey = "pingone.services.sso.resources.rules"
+)
+
+var (
+	resourceConfigurationParamMapping = map[string]string{
+		resourceNamesParamName:             resourceNamesParamConfigKey,
+		resourceNamePatternsParamName:      resourceNamePatternsParamConfigKey,
+		resourceScopeNamesParamName:        resourceScopeNamesParamConfigKey,
+		resourceScopeNamePatternsParamName: resourceScopeNamePatternsParamConfigKey,
+		resourceOrphanedParamName:          resourceOrphanedParamConfigKey,
+	}
+)
+
+var cleanResourcesCmd = &cobra.Command{
+	Use:   resourcesCmdName,
+	Short: "Clean unwanted custom resources and scopes",
+	Long: fmt.Sprintf(`Clean away demo and test custom resources and resource scopes, selected by name, name pattern, or (for resources) because none of their scopes are granted to an application.
+
+	The built-in openid and PingOne API resources are never modified.  Scopes that are granted to applications are reported as blocked and are not deleted.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Demo API" --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "^test:" --%s --%s
+	
+	`, resourcesCmdName, environmentIDParamName, resourceNamesParamName, dryRunParamName, resourcesCmdName, environmentIDParamName, resourceScopeNamePatternsParamName, resourceOrphanedParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		resourceNames := viper.GetStringSlice(resourceNamesParamConfigKey)
+		resourceNamePatterns := viper.GetStringSlice(resourceNamePatternsParamConfigKey)
+		resourceScopeNames := viper.GetStringSlice(resourceScopeNamesParamConfigKey)
+		resourceScopeNamePatterns := viper.GetStringSlice(resourceScopeNamePatternsParamConfigKey)
+		resourceOrphaned := viper.GetBool(resourceOrphanedParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for resources.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Resource names: "%s"`, strings.Join(resourceNames, `", "`))
+		l.Debug().Msgf(`Resource name patterns: "%s"`, strings.Join(resourceNamePatterns, `", "`))
+		l.Debug().Msgf(`Scope names: "%s"`, strings.Join(resourceScopeNames, `", "`))
+		l.Debug().Msgf(`Scope name patterns: "%s"`, strings.Join(resourceScopeNamePatterns, `", "`))
+		l.Debug().Msgf("Orphaned setting: %t", resourceOrphaned)
+
+		patterns, err := compilePatterns(resourceNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		scopePatterns, err := compilePatterns(resourceScopeNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(resourceRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := sso.CleanEnvironmentResourcesConfig{
+			Environment:                    environment,
+			BootstrapResourceNames:         resourceNames,
+			BootstrapResourcePatterns:      patterns,
+			BootstrapResourceScopeNames:    resourceScopeNames,
+			BootstrapResourceScopePatterns: scopePatterns,
+			Orphaned:                       resourceOrphaned,
+			Rules:                          rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanResourcesCmd.PersistentFlags().StringSliceVar(&resourceNames, resourceNamesParamName, []string{}, "The list of custom resource names to search for to delete.")
+	cleanResourcesCmd.PersistentFlags().StringSliceVar(&resourceNamePatterns, resourceNamePatternsParamName, []string{}, "The list of regular expressions to match custom resource names to delete.")
+	cleanResourcesCmd.PersistentFlags().StringSliceVar(&resourceScopeNames, resourceScopeNamesParamName, []string{}, "The list of custom resource scope names to search for to delete.")
+	cleanResourcesCmd.PersistentFlags().StringSliceVar(&resourceScopeNamePatterns, resourceScopeNamePatternsParamName, []string{}, "The list of regular expressions to match custom resource scope names to delete.")
+	cleanResourcesCmd.PersistentFlags().BoolVar(&resourceOrphaned, resourceOrphanedParamName, false, "Delete custom resources whose scopes are not granted to any application.")
+
+	if err := bindParams(resourceConfigurationParamMapping, cleanResourcesCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/clean/services/protect"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/spf13/cobra"
@@ -12,7 +11,8 @@ import (
 )
 
 var (
-	riskPolicyNames []string
+	riskPolicyNames        []string
+	riskPolicyFingerprints map[string]string
 )
 
 const (
@@ -20,11 +20,17 @@ const (
 
 	riskPolicyNamesParamName      = "policy-name"
 	riskPolicyNamesParamConfigKey = "pingone.services.protect.risk-policies.names"
+
+	riskPolicyFingerprintsParamName      = "bootstrap-fingerprint"
+	riskPolicyFingerprintsParamConfigKey = "pingone.services.protect.risk-policies.fingerprints"
+
+	riskPolicyRulesConfigKey = "pingone.services.protect.risk-policies.rules"
 )
 
 var (
 	riskPolicyConfigurationParamMapping = map[string]string{
-		riskPolicyNamesParamName: riskPolicyNamesParamConfigKey,
+		riskPolicyNamesParamName:        riskPolicyNamesParamConfigKey,
+		riskPolicyFingerprintsParamName: riskPolicyFingerprintsParamConfigKey,
 	}
 )
 
@@ -44,6 +50,7 @@ var cleanRiskPoliciesCmd = &cobra.Command{
 
 		dryRun := viper.GetBool(dryRunParamConfigKey)
 		riskPolicyNames := viper.GetStringSlice(riskPolicyNamesParamConfigKey)
+		riskPolicyFingerprints := viper.GetStringMapString(riskPolicyFingerprintsParamConfigKey)
 
 		l.Debug().Msgf("Clean Command called for Risk policies.")
 		l.Debug().Msgf("Dry run setting: %t", dryRun)
@@ -55,13 +62,21 @@ var cleanRiskPoliciesCmd = &cobra.Command{
 			return err
 		}
 
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(riskPolicyRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
 		cleanConfig := protect.CleanEnvironmentProtectRiskPoliciesConfig{
-			Environment: clean.CleanEnvironmentConfig{
-				EnvironmentID: viper.GetString(environmentIDParamConfigKey),
-				DryRun:        dryRun,
-				Client:        apiClient.API,
-			},
-			BootstrapRiskPolicyNames: riskPolicyNames,
+			Environment:                     environment,
+			BootstrapRiskPolicyNames:        riskPolicyNames,
+			BootstrapRiskPolicyFingerprints: riskPolicyFingerprints,
+			Rules:                           rules,
 		}
 
 		return cleanConfig.Clean(cmd.Context())
@@ -72,6 +87,7 @@ func init() {
 	l := logger.Get()
 
 	cleanRiskPoliciesCmd.PersistentFlags().StringSliceVar(&riskPolicyNames, riskPolicyNamesParamName, protect.BootstrapRiskPolicyNames, "The list of Risk policy names to search for to delete.  Case sensitive.")
+	cleanRiskPoliciesCmd.PersistentFlags().StringToStringVar(&riskPolicyFingerprints, riskPolicyFingerprintsParamName, protect.BootstrapRiskPolicyFingerprints, "The fingerprints of the pristine bootstrap risk policy set configuration, in the format name=fingerprint.  Items with a known fingerprint are classified as pristine, modified or renamed-pristine.")
 
 	if err := bindParams(riskPolicyConfigurationParamMapping, cleanRiskPoliciesCmd); err != nil {
 		l.Err(err).Msgf("Error binding parameters: %s", err)
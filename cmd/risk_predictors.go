@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/protect"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	riskPredictorNames        []string
+	riskPredictorNamePatterns []string
+	riskPredictorUnreferenced bool
+)
+
+const (
+	riskPredictorsCmdName = "risk-predictors"
+
+	riskPredictorNamesParamName      = "predictor-name"
+	riskPredictorNamesParamConfigKey = "pingone.services.protect.risk-predictors.names"
+
+	riskPredictorNamePatternsParamName      = "predictor-name-pattern"
+	riskPredictorNamePatternsParamConfigKey = "pingone.services.protect.risk-predictors.name-patterns"
+
+	riskPredictorUnreferencedParamName      = "unreferenced"
+	riskPredictorUnreferencedParamConfigKey = "pingone.services.protect.risk-predictors.unreferenced"
+
+	riskPredictorRulesConfigKey = "pingone.services.protect.risk-predictors.rules"
+)
+
+var (
+	riskPredictorConfigurationParamMapping = map[string]string{
+		riskPredictorNamesParamName:        riskPredictorNamesParamConfigKey,
+		riskPredictorNamePatternsParamName: riskPredictorNamePatternsParamConfigKey,
+		riskPredictorUnreferencedParamName: riskPredictorUnreferencedParamConfigKey,
+	}
+)
+
+var cleanRiskPredictorsCmd = &cobra.Command{
+	Use:   riskPredictorsCmdName,
+	Short: "Clean unwanted demo and test custom Risk predictors",
+	Long: fmt.Sprintf(`Clean away demo and test custom Risk predictors, selected by name, name pattern, or because no risk policy set refers to them.
+
+	Built-in predictors are never modified.  Predictors used by a risk policy set are reported as blocked and are not deleted.  Custom predictors that no risk policy set refers to are
+	always flagged for review, and are only deleted when the reference check is switched on.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Test Predictor" --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s --%s
+	
+	`, riskPredictorsCmdName, environmentIDParamName, riskPredictorNamesParamName, dryRunParamName, riskPredictorsCmdName, environmentIDParamName, riskPredictorUnreferencedParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		riskPredictorNames := viper.GetStringSlice(riskPredictorNamesParamConfigKey)
+		riskPredictorNamePatterns := viper.GetStringSlice(riskPredictorNamePatternsParamConfigKey)
+		riskPredictorUnreferenced := viper.GetBool(riskPredictorUnreferencedParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for risk predictors.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Risk predictor names: "%s"`, strings.Join(riskPredictorNames, `", "`))
+		l.Debug().Msgf(`Risk predictor name patterns: "%s"`, strings.Join(riskPredictorNamePatterns, `", "`))
+		l.Debug().Msgf("Unreferenced setting: %t", riskPredictorUnreferenced)
+
+		patterns, err := compilePatterns(riskPredictorNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(riskPredictorRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := protect.CleanEnvironmentProtectRiskPredictorsConfig{
+			Environment:                    environment,
+			BootstrapRiskPredictorNames:    riskPredictorNames,
+			BootstrapRiskPredictorPatterns: patterns,
+			Unreferenced:                   riskPredictorUnreferenced,
+			Rules:                          rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanRiskPredictorsCmd.PersistentFlags().StringSliceVar(&riskPredictorNames, riskPredictorNamesParamName, []string{}, "The list of custom predictor names to search for to delete.")
+	cleanRiskPredictorsCmd.PersistentFlags().StringSliceVar(&riskPredictorNamePatterns, riskPredictorNamePatternsParamName, []string{}, "The list of regular expressions to match custom predictor names to delete.")
+	cleanRiskPredictorsCmd.PersistentFlags().BoolVar(&riskPredictorUnreferenced, riskPredictorUnreferencedParamName, false, "Delete custom predictors that no risk policy set refers to.  They are flagged for review either way.")
+
+	if err := bindParams(riskPredictorConfigurationParamMapping, cleanRiskPredictorsCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
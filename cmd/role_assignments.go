@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/sso"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	roleAssignmentRevoke bool
+)
+
+const (
+	roleAssignmentsCmdName = "role-assignments"
+
+	roleAssignmentRevokeParamName      = "revoke"
+	roleAssignmentRevokeParamConfigKey = "pingone.services.sso.role-assignments.revoke"
+
+	roleAssignmentRulesConfigKey = "pingone.services.sso.role-assignments.rules"
+)
+
+var (
+	roleAssignmentConfigurationParamMapping = map[string]string{
+		roleAssignmentRevokeParamName: roleAssignmentRevokeParamConfigKey,
+	}
+)
+
+var cleanRoleAssignmentsCmd = &cobra.Command{
+	Use:   roleAssignmentsCmdName,
+	Short: "Audit over-broad admin role assignments",
+	Long: fmt.Sprintf(`List the admin role assignments of users, groups and applications in the environment, and flag those that are over-broad:
+	organization scoped assignments, the Environment Admin role assigned to an application and assignments held by disabled users.  Further
+	assignments can be flagged with rules.
+
+	Flagged assignments are only revoked when revoking is switched on.  The worker application running the sweep is never listed or revoked.
+
+	The audit makes a request per user, group and application, so it is not run when cleaning all services and must be run on its own.
+
+	Examples:
+
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s --%s
+
+	`, roleAssignmentsCmdName, environmentIDParamName, roleAssignmentsCmdName, environmentIDParamName, roleAssignmentRevokeParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		roleAssignmentRevoke := viper.GetBool(roleAssignmentRevokeParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for role assignments.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf("Revoke setting: %t", roleAssignmentRevoke)
+
+		var err error
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(roleAssignmentRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := sso.CleanEnvironmentRoleAssignmentsConfig{
+			Environment:    environment,
+			WorkerClientID: viper.GetString(workerClientIDParamConfigKey),
+			Revoke:         roleAssignmentRevoke,
+			Rules:          rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanRoleAssignmentsCmd.PersistentFlags().BoolVar(&roleAssignmentRevoke, roleAssignmentRevokeParamName, false, "Revoke flagged admin role assignments instead of only listing them.")
+
+	if err := bindParams(roleAssignmentConfigurationParamMapping, cleanRoleAssignmentsCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
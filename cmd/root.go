@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"time"
 
+	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/patrickcping/pingone-sweep/internal/sdk"
 	"github.com/spf13/cobra"
@@ -31,6 +34,33 @@ const (
 	dryRunParamName      = "dry-run"
 	dryRunParamConfigKey = "dry-run"
 
+	includeModifiedParamName      = "include-modified"
+	includeModifiedParamConfigKey = "include-modified"
+
+	bootstrapWindowParamName      = "bootstrap-window"
+	bootstrapWindowParamConfigKey = "bootstrap-window"
+
+	protectionMarkerParamName      = "protection-marker"
+	protectionMarkerParamConfigKey = "protection.marker"
+
+	protectedIDsFileParamName      = "protected-ids-file"
+	protectedIDsFileParamConfigKey = "protection.ids-file"
+
+	protectedNamesParamName      = "protected-name"
+	protectedNamesParamConfigKey = "protection.names"
+
+	desiredStateFileParamName      = "desired-state-file"
+	desiredStateFileParamConfigKey = "desired-state-file"
+
+	interactiveParamName      = "interactive"
+	interactiveParamConfigKey = "interactive"
+
+	pickParamName      = "pick"
+	pickParamConfigKey = "pick"
+
+	rateLimitParamName      = "rate-limit"
+	rateLimitParamConfigKey = "pingone.rate-limit"
+
 	outputJsonParamName      = "json"
 	outputJsonParamConfigKey = "output.json"
 
@@ -54,14 +84,46 @@ var (
 	workerClientSecret  string
 	environmentID       string
 	dryRun              bool
+	includeModified     bool
+	bootstrapWindow     time.Duration
+	protectionMarker    string
+	protectedIDsFile    string
+	protectedNames      []string
+	desiredStateFile    string
+	interactive         bool
+	pick                bool
+	rateLimit           int
 	outputJson          bool
 	outputNoColor       bool
 	apiClient           *sdk.Client
 
+	environmentCreatedAt *time.Time
+	prompter             *clean.Prompter
+	plan                 *clean.Plan
+	desiredState         clean.DesiredState
+
+	// Commands that are not run when cleaning all services: commands that are not clean commands, and audits that make a request per user, group and
+	// application, which are too costly to run on every sweep
+	cleanAllExcludedCommands = map[string]bool{
+		"completion":           true,
+		"help":                 true,
+		hardenCmdName:          true,
+		roleAssignmentsCmdName: true,
+	}
+
 	rootConfigurationParamMapping = map[string]string{
 		regionParamName:              regionParamConfigKey,
 		environmentIDParamName:       environmentIDParamConfigKey,
 		dryRunParamName:              dryRunParamConfigKey,
+		includeModifiedParamName:     includeModifiedParamConfigKey,
+		bootstrapWindowParamName:     bootstrapWindowParamConfigKey,
+		protectionMarkerParamName:    protectionMarkerParamConfigKey,
+		protectedIDsFileParamName:    protectedIDsFileParamConfigKey,
+		protectedNamesParamName:      protectedNamesParamConfigKey,
+		desiredStateFileParamName:    desiredStateFileParamConfigKey,
+		interactiveParamName:         interactiveParamConfigKey,
+		pickParamName:                pickParamConfigKey,
+		rateLimitParamName:           rateLimitParamConfigKey,
 		outputJsonParamName:          outputJsonParamConfigKey,
 		outputNoColorParamName:       outputNoColorParamConfigKey,
 		workerEnvironmentIDParamName: workerEnvironmentIDParamConfigKey,
@@ -93,6 +155,27 @@ var rootCmd = &cobra.Command{
 
 		return nil
 	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		if plan == nil || len(plan.Items) == 0 {
+			return nil
+		}
+
+		l.Debug().Msgf("Opening item picker for %d planned actions..", len(plan.Items))
+
+		confirmed, err := plan.Pick(os.Stdin, os.Stdout)
+		if err != nil {
+			return err
+		}
+
+		if !confirmed {
+			fmt.Println("Selection cancelled - no configuration has been modified.")
+			return nil
+		}
+
+		return plan.Apply(cmd.Context())
+	},
 	Version: fmt.Sprintf("%s-%s", version, commit),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		l := logger.Get()
@@ -107,7 +190,7 @@ var rootCmd = &cobra.Command{
 		commands := cmd.Commands()
 
 		for _, command := range commands {
-			if command.Name() != "completion" && command.Name() != "help" {
+			if !cleanAllExcludedCommands[command.Name()] {
 				l.Debug().Msgf("Running command: %s", command.Name())
 				err := command.RunE(cmd, args)
 				if err != nil {
@@ -134,17 +217,45 @@ func init() {
 
 	// General function commands
 	rootCmd.AddCommand(
+		cleanAgreementsCmd,
+		cleanApplicationsCmd,
 		cleanAuthenticationPoliciesCmd,
+		cleanAuthorizeCmd,
+		cleanBrandingSettingsCmd,
 		cleanBrandingThemesCmd,
+		cleanCertificatesCmd,
+		cleanCredentialTypesCmd,
+		cleanCustomDomainsCmd,
 		cleanDaVinciFormsCmd,
+		cleanDigitalWalletAppsCmd,
 		cleanDirectoryAttributesCmd,
+		cleanGatewaysCmd,
+		cleanGroupsCmd,
+		cleanIdentityProvidersCmd,
 		cleanKeysCmd,
+		cleanLanguagesCmd,
 		cleanMfaDevicePoliciesCmd,
 		cleanMfaFido2PoliciesCmd,
+		cleanMfaSettingsCmd,
 		cleanNotificationPoliciesCmd,
+		cleanNotificationTemplatesCmd,
 		cleanPasswordPoliciesCmd,
+		cleanPhoneDeliverySettingsCmd,
+		cleanPopulationsCmd,
+		cleanResourcesCmd,
 		cleanRiskPoliciesCmd,
+		cleanRiskPredictorsCmd,
+		cleanRoleAssignmentsCmd,
+		cleanSubscriptionsCmd,
+		cleanTrustedEmailDomainsCmd,
+		cleanUsersCmd,
 		cleanVerifyPoliciesCmd,
+		cleanVerifyVoicePhrasesCmd,
+	)
+
+	// Environment hardening
+	rootCmd.AddCommand(
+		hardenCmd,
 	)
 
 	// Add config flags
@@ -169,6 +280,29 @@ func init() {
 	// Dry run
 	rootCmd.PersistentFlags().BoolVar(&dryRun, dryRunParamName, false, "Run a clean routine but don't delete any configuration - instead issue a warning if configuration were to be deleted.")
 
+	// Include modified
+	rootCmd.PersistentFlags().BoolVar(&includeModified, includeModifiedParamName, false, "Include bootstrap configuration that has been modified from its pristine state (where the service supports fingerprinting).  By default, bootstrap configuration with a stored fingerprint is only removed when it is pristine.")
+
+	// Bootstrap window
+	rootCmd.PersistentFlags().DurationVar(&bootstrapWindow, bootstrapWindowParamName, 0, "Only treat configuration as bootstrap configuration if it was created within this duration of the target environment's creation (for example 5m).  Items without a creation time are not acted on while the window is set.  Disabled by default.")
+
+	// Protection
+	rootCmd.PersistentFlags().StringVar(&protectionMarker, protectionMarkerParamName, clean.DefaultProtectionMarker, "Configuration with this marker in its description or name is never modified.  Configuration without a description, such as branding themes, can only be marked in its name.  Set to an empty string to disable.")
+	rootCmd.PersistentFlags().StringVar(&protectedIDsFile, protectedIDsFileParamName, "", "The path to a file of configuration IDs that are never modified, one per line.")
+	rootCmd.PersistentFlags().StringSliceVar(&protectedNames, protectedNamesParamName, []string{}, "The list of configuration names that are never modified, across all services.")
+
+	// Singleton reset
+	rootCmd.PersistentFlags().StringVar(&desiredStateFile, desiredStateFileParamName, "", "The path to a YAML file of the desired state of singleton settings (such as MFA settings), which are reset to match where they differ.")
+
+	// Interactive selection
+	rootCmd.PersistentFlags().BoolVar(&interactive, interactiveParamName, false, "Ask for confirmation before each configuration item is modified.")
+	rootCmd.PersistentFlags().BoolVar(&pick, pickParamName, false, "Collect the configuration items to be modified across the selected services, then choose the items to modify from a full-screen list before any are applied.")
+
+	rootCmd.MarkFlagsMutuallyExclusive(interactiveParamName, pickParamName)
+
+	// Rate limit
+	rootCmd.PersistentFlags().IntVar(&rateLimit, rateLimitParamName, 20, "The maximum number of requests per second made to the PingOne API, shared across all concurrent requests.  Set to 0 to disable.")
+
 	// Output format
 	rootCmd.PersistentFlags().BoolVar(&outputJson, outputJsonParamName, false, "Output in JSON format.")
 
@@ -215,6 +349,8 @@ func initApiClient(ctx context.Context, version string) (*sdk.Client, error) {
 
 	l.Debug().Msgf("Initialising API client..")
 
+	sdk.SetRequestRateLimit(viper.GetInt(rateLimitParamConfigKey))
+
 	apiConfig := sdk.Config{
 		ClientID:      viper.GetString(workerClientIDParamConfigKey),
 		ClientSecret:  viper.GetString(workerClientSecretParamConfigKey),
@@ -226,6 +362,112 @@ func initApiClient(ctx context.Context, version string) (*sdk.Client, error) {
 
 }
 
+func initCleanEnvironmentConfig(ctx context.Context) (clean.CleanEnvironmentConfig, error) {
+	l := logger.Get()
+
+	env := clean.CleanEnvironmentConfig{
+		EnvironmentID:   viper.GetString(environmentIDParamConfigKey),
+		DryRun:          viper.GetBool(dryRunParamConfigKey),
+		IncludeModified: viper.GetBool(includeModifiedParamConfigKey),
+		Protection: clean.ProtectionConfig{
+			Marker: viper.GetString(protectionMarkerParamConfigKey),
+			Names:  viper.GetStringSlice(protectedNamesParamConfigKey),
+		},
+		Client: apiClient.API,
+	}
+
+	if viper.GetBool(pickParamConfigKey) {
+		if plan == nil {
+			plan = clean.NewPlan()
+		}
+		env.Plan = plan
+	} else if viper.GetBool(interactiveParamConfigKey) {
+		if prompter == nil {
+			prompter = clean.NewPrompter(os.Stdin, os.Stdout)
+		}
+		env.Prompter = prompter
+	}
+
+	if protectedIDsFile := viper.GetString(protectedIDsFileParamConfigKey); protectedIDsFile != "" {
+		l.Debug().Msgf(`Protected ID file: "%s"`, protectedIDsFile)
+
+		ids, err := clean.ReadProtectedIDsFile(protectedIDsFile)
+		if err != nil {
+			return env, err
+		}
+
+		env.Protection.IDs = ids
+	}
+
+	if bootstrapWindow := viper.GetDuration(bootstrapWindowParamConfigKey); bootstrapWindow > 0 {
+		l.Debug().Msgf("Bootstrap window setting: %s", bootstrapWindow)
+
+		if environmentCreatedAt == nil {
+			var err error
+			environmentCreatedAt, err = clean.ReadEnvironmentCreatedAt(ctx, env)
+			if err != nil {
+				return env, err
+			}
+		}
+
+		env.BootstrapWindow = &bootstrapWindow
+		env.EnvironmentCreatedAt = environmentCreatedAt
+	}
+
+	return env, nil
+}
+
+// readDesiredState returns the desired state of the named singleton settings from the desired state file, or nil if none is configured
+func readDesiredState(name string) (map[string]any, error) {
+	l := logger.Get()
+
+	desiredStateFile := viper.GetString(desiredStateFileParamConfigKey)
+	if desiredStateFile == "" {
+		return nil, nil
+	}
+
+	if desiredState == nil {
+		l.Debug().Msgf(`Desired state file: "%s"`, desiredStateFile)
+
+		var err error
+		desiredState, err = clean.ReadDesiredStateFile(desiredStateFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return desiredState[name], nil
+}
+
+func compileRules(configKey string) ([]clean.Rule, error) {
+	l := logger.Get()
+
+	rules, err := clean.CompileRules(viper.GetStringMapString(configKey))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		l.Debug().Msgf(`Rule "%s": %s`, rule.Name, rule.Expression)
+	}
+
+	return rules, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiledPatterns := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		compiledPattern, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid name pattern \"%s\": %w", pattern, err)
+		}
+		compiledPatterns = append(compiledPatterns, compiledPattern)
+	}
+
+	return compiledPatterns, nil
+}
+
 func bindParams(paramlist map[string]string, command *cobra.Command) error {
 	// Do the binds
 	for k, v := range paramlist {
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	subscriptionNames            []string
+	subscriptionNamePatterns     []string
+	subscriptionDestinationHosts []string
+	subscriptionPlaceholderHosts []string
+	subscriptionDisabled         bool
+	subscriptionDisable          bool
+)
+
+const (
+	subscriptionsCmdName = "subscriptions"
+
+	subscriptionNamesParamName      = "subscription-name"
+	subscriptionNamesParamConfigKey = "pingone.services.platform.subscriptions.names"
+
+	subscriptionNamePatternsParamName      = "subscription-name-pattern"
+	subscriptionNamePatternsParamConfigKey = "pingone.services.platform.subscriptions.name-patterns"
+
+	subscriptionDestinationHostsParamName      = "destination-host"
+	subscriptionDestinationHostsParamConfigKey = "pingone.services.platform.subscriptions.destination-hosts"
+
+	subscriptionPlaceholderHostsParamName      = "placeholder-host"
+	subscriptionPlaceholderHostsParamConfigKey = "pingone.services.platform.subscriptions.placeholder-hosts"
+
+	subscriptionDisabledParamName      = "disabled"
+	subscriptionDisabledParamConfigKey = "pingone.services.platform.subscriptions.disabled"
+
+	subscriptionDisableParamName      = "disable"
+	subscriptionDisableParamConfigKey = "pingone.services.platform.subscriptions.disable"
+
+	subscriptionRulesConfigKey = "pingone.services.platform.subscriptions.rules"
+)
+
+var (
+	subscriptionConfigurationParamMapping = map[string]string{
+		subscriptionNamesParamName:            subscriptionNamesParamConfigKey,
+		subscriptionNamePatternsParamName:     subscriptionNamePatternsParamConfigKey,
+		subscriptionDestinationHostsParamName: subscriptionDestinationHostsParamConfigKey,
+		subscriptionPlaceholderHostsParamName: subscriptionPlaceholderHostsParamConfigKey,
+		subscriptionDisabledParamName:         subscriptionDisabledParamConfigKey,
+		subscriptionDisableParamName:          subscriptionDisableParamConfigKey,
+	}
+)
+
+var cleanSubscriptionsCmd = &cobra.Command{
+	Use:   subscriptionsCmdName,
+	Short: "Clean unwanted demo and test webhook subscriptions",
+	Long: fmt.Sprintf(`Clean away demo and test webhook subscriptions, selected by name, name pattern, destination host or disabled state.
+
+	Subscriptions that send events to a non-HTTPS destination or to a placeholder host (for example webhook.site or localhost) are reported, whether or not they are selected.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s webhook.site --%s --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "^Test " --%s --%s
+	
+	`, subscriptionsCmdName, environmentIDParamName, dryRunParamName, subscriptionsCmdName, environmentIDParamName, subscriptionDestinationHostsParamName, subscriptionDisabledParamName, dryRunParamName, subscriptionsCmdName, environmentIDParamName, subscriptionNamePatternsParamName, subscriptionDisableParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		subscriptionNames := viper.GetStringSlice(subscriptionNamesParamConfigKey)
+		subscriptionNamePatterns := viper.GetStringSlice(subscriptionNamePatternsParamConfigKey)
+		subscriptionDestinationHosts := viper.GetStringSlice(subscriptionDestinationHostsParamConfigKey)
+		subscriptionPlaceholderHosts := viper.GetStringSlice(subscriptionPlaceholderHostsParamConfigKey)
+		subscriptionDisabled := viper.GetBool(subscriptionDisabledParamConfigKey)
+		subscriptionDisable := viper.GetBool(subscriptionDisableParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for subscriptions.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Subscription names: "%s"`, strings.Join(subscriptionNames, `", "`))
+		l.Debug().Msgf(`Subscription name patterns: "%s"`, strings.Join(subscriptionNamePatterns, `", "`))
+		l.Debug().Msgf(`Destination hosts: "%s"`, strings.Join(subscriptionDestinationHosts, `", "`))
+		l.Debug().Msgf(`Placeholder hosts: "%s"`, strings.Join(subscriptionPlaceholderHosts, `", "`))
+		l.Debug().Msgf("Disabled setting: %t", subscriptionDisabled)
+		l.Debug().Msgf("Disable setting: %t", subscriptionDisable)
+
+		patterns, err := compilePatterns(subscriptionNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(subscriptionRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := platform.CleanEnvironmentPlatformSubscriptionsConfig{
+			Environment:                   environment,
+			BootstrapSubscriptionNames:    subscriptionNames,
+			BootstrapSubscriptionPatterns: patterns,
+			DestinationHosts:              subscriptionDestinationHosts,
+			PlaceholderHosts:              subscriptionPlaceholderHosts,
+			Disabled:                      subscriptionDisabled,
+			Disable:                       subscriptionDisable,
+			Rules:                         rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanSubscriptionsCmd.PersistentFlags().StringSliceVar(&subscriptionNames, subscriptionNamesParamName, []string{}, "The list of subscription names to search for to delete.")
+	cleanSubscriptionsCmd.PersistentFlags().StringSliceVar(&subscriptionNamePatterns, subscriptionNamePatternsParamName, []string{}, "The list of regular expressions to match subscription names to delete.")
+	cleanSubscriptionsCmd.PersistentFlags().StringSliceVar(&subscriptionDestinationHosts, subscriptionDestinationHostsParamName, []string{}, "The list of destination URL hosts (including their subdomains) of subscriptions to delete.")
+	cleanSubscriptionsCmd.PersistentFlags().StringSliceVar(&subscriptionPlaceholderHosts, subscriptionPlaceholderHostsParamName, platform.DefaultPlaceholderHosts, "The list of destination URL hosts that are reported as placeholders.")
+	cleanSubscriptionsCmd.PersistentFlags().BoolVar(&subscriptionDisabled, subscriptionDisabledParamName, false, "Delete subscriptions that are disabled.")
+	cleanSubscriptionsCmd.PersistentFlags().BoolVar(&subscriptionDisable, subscriptionDisableParamName, false, "Disable matching subscriptions instead of deleting them.")
+
+	if err := bindParams(subscriptionConfigurationParamMapping, cleanSubscriptionsCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
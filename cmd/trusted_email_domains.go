@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/platform"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	trustedEmailDomainNamePatterns            []string
+	trustedEmailDomainUnverifiedOlderThanDays int
+)
+
+const (
+	trustedEmailDomainsCmdName = "trusted-email-domains"
+
+	trustedEmailDomainNamePatternsParamName      = "trusted-email-domain-name-pattern"
+	trustedEmailDomainNamePatternsParamConfigKey = "pingone.services.platform.trusted-email-domains.name-patterns"
+
+	trustedEmailDomainUnverifiedOlderThanDaysParamName      = "unverified-older-than-days"
+	trustedEmailDomainUnverifiedOlderThanDaysParamConfigKey = "pingone.services.platform.trusted-email-domains.unverified-older-than-days"
+
+	trustedEmailDomainRulesConfigKey = "pingone.services.platform.trusted-email-domains.rules"
+)
+
+var (
+	trustedEmailDomainConfigurationParamMapping = map[string]string{
+		trustedEmailDomainNamePatternsParamName:            trustedEmailDomainNamePatternsParamConfigKey,
+		trustedEmailDomainUnverifiedOlderThanDaysParamName: trustedEmailDomainUnverifiedOlderThanDaysParamConfigKey,
+	}
+)
+
+var cleanTrustedEmailDomainsCmd = &cobra.Command{
+	Use:   trustedEmailDomainsCmdName,
+	Short: "Clean unverified and test trusted email domains",
+	Long: fmt.Sprintf(`Clean away trusted email domains whose ownership has not been verified for longer than a number of days, or whose domain name matches a pattern.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s 7 --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "\.test\.example\.com$" --%s
+	
+	`, trustedEmailDomainsCmdName, environmentIDParamName, trustedEmailDomainUnverifiedOlderThanDaysParamName, dryRunParamName, trustedEmailDomainsCmdName, environmentIDParamName, trustedEmailDomainNamePatternsParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		trustedEmailDomainNamePatterns := viper.GetStringSlice(trustedEmailDomainNamePatternsParamConfigKey)
+		trustedEmailDomainUnverifiedOlderThanDays := viper.GetInt(trustedEmailDomainUnverifiedOlderThanDaysParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for trusted email domains.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Trusted email domain name patterns: "%s"`, strings.Join(trustedEmailDomainNamePatterns, `", "`))
+		l.Debug().Msgf("Unverified older than days: %d", trustedEmailDomainUnverifiedOlderThanDays)
+
+		patterns, err := compilePatterns(trustedEmailDomainNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(trustedEmailDomainRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := platform.CleanEnvironmentPlatformTrustedEmailDomainsConfig{
+			Environment:             environment,
+			BootstrapDomainPatterns: patterns,
+			UnverifiedOlderThanDays: trustedEmailDomainUnverifiedOlderThanDays,
+			Rules:                   rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanTrustedEmailDomainsCmd.PersistentFlags().StringSliceVar(&trustedEmailDomainNamePatterns, trustedEmailDomainNamePatternsParamName, []string{}, "The list of regular expressions to match trusted email domain names to delete.")
+	cleanTrustedEmailDomainsCmd.PersistentFlags().IntVar(&trustedEmailDomainUnverifiedOlderThanDays, trustedEmailDomainUnverifiedOlderThanDaysParamName, 0, "Delete trusted email domains whose ownership has not been verified and were created more than this number of days ago.  Zero disables the check.")
+
+	if err := bindParams(trustedEmailDomainConfigurationParamMapping, cleanTrustedEmailDomainsCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
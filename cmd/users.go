@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/sso"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	userFilter              string
+	userPopulationNames     []string
+	userLastSignOnOlderThan int
+	userMaxDeletes          int
+	userDisable             bool
+)
+
+const (
+	usersCmdName = "users"
+
+	userFilterParamName      = "user-filter"
+	userFilterParamConfigKey = "pingone.services.sso.users.filter"
+
+	userPopulationNamesParamName      = "user-population"
+	userPopulationNamesParamConfigKey = "pingone.services.sso.users.populations"
+
+	userLastSignOnOlderThanParamName      = "last-sign-on-older-than-days"
+	userLastSignOnOlderThanParamConfigKey = "pingone.services.sso.users.last-sign-on-older-than-days"
+
+	userMaxDeletesParamName      = "max-deletes"
+	userMaxDeletesParamConfigKey = "pingone.services.sso.users.max-deletes"
+
+	userDisableParamName      = "disable"
+	userDisableParamConfigKey = "pingone.services.sso.users.disable"
+
+	userRulesConfigKey = "pingone.services.sso.users.rules"
+)
+
+var (
+	userConfigurationParamMapping = map[string]string{
+		userFilterParamName:              userFilterParamConfigKey,
+		userPopulationNamesParamName:     userPopulationNamesParamConfigKey,
+		userLastSignOnOlderThanParamName: userLastSignOnOlderThanParamConfigKey,
+		userMaxDeletesParamName:          userMaxDeletesParamConfigKey,
+		userDisableParamName:             userDisableParamConfigKey,
+	}
+)
+
+var cleanUsersCmd = &cobra.Command{
+	Use:   usersCmdName,
+	Short: "Clean test and stale users",
+	Long: fmt.Sprintf(`Clean away test and stale users, selected with a SCIM filter, by population or by the time of their last sign-on.
+
+	No users are selected unless a filter, population, last sign-on age or rule is configured.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s 'username sw "tf-acc-"' --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Sample Users" --%s 90 --%s 500 --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s 180 --%s --%s
+	
+	`, usersCmdName, environmentIDParamName, userFilterParamName, dryRunParamName, usersCmdName, environmentIDParamName, userPopulationNamesParamName, userLastSignOnOlderThanParamName, userMaxDeletesParamName, dryRunParamName, usersCmdName, environmentIDParamName, userLastSignOnOlderThanParamName, userDisableParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		userFilter := viper.GetString(userFilterParamConfigKey)
+		userPopulationNames := viper.GetStringSlice(userPopulationNamesParamConfigKey)
+		userLastSignOnOlderThan := viper.GetInt(userLastSignOnOlderThanParamConfigKey)
+		userMaxDeletes := viper.GetInt(userMaxDeletesParamConfigKey)
+		userDisable := viper.GetBool(userDisableParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for users.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`User filter: "%s"`, userFilter)
+		l.Debug().Msgf(`User populations: "%s"`, strings.Join(userPopulationNames, `", "`))
+		l.Debug().Msgf("Last sign-on older than days: %d", userLastSignOnOlderThan)
+		l.Debug().Msgf("Max deletes: %d", userMaxDeletes)
+		l.Debug().Msgf("Disable setting: %t", userDisable)
+
+		var err error
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(userRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := sso.CleanEnvironmentUsersConfig{
+			Environment:             environment,
+			Filter:                  userFilter,
+			PopulationNames:         userPopulationNames,
+			LastSignOnOlderThanDays: userLastSignOnOlderThan,
+			MaxDeletes:              userMaxDeletes,
+			Disable:                 userDisable,
+			Rules:                   rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanUsersCmd.PersistentFlags().StringVar(&userFilter, userFilterParamName, "", "A SCIM filter to select the users to delete (for example 'username sw \"tf-acc-\"').")
+	cleanUsersCmd.PersistentFlags().StringSliceVar(&userPopulationNames, userPopulationNamesParamName, []string{}, "The list of population names whose users are selected to delete.")
+	cleanUsersCmd.PersistentFlags().IntVar(&userLastSignOnOlderThan, userLastSignOnOlderThanParamName, 0, "Only select users whose last sign-on (or creation, if they have never signed on) is older than this number of days.  Disabled by default.")
+	cleanUsersCmd.PersistentFlags().IntVar(&userMaxDeletes, userMaxDeletesParamName, 100, "The maximum number of users to delete (or disable) in a single run.  Set to 0 to remove the limit.")
+	cleanUsersCmd.PersistentFlags().BoolVar(&userDisable, userDisableParamName, false, "Disable matching users instead of deleting them.")
+
+	if err := bindParams(userConfigurationParamMapping, cleanUsersCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/patrickcping/pingone-sweep/internal/clean"
 	"github.com/patrickcping/pingone-sweep/internal/clean/services/verify"
 	"github.com/patrickcping/pingone-sweep/internal/logger"
 	"github.com/spf13/cobra"
@@ -21,6 +20,8 @@ const (
 
 	verifyPolicyNamesParamName      = "policy-name"
 	verifyPolicyNamesParamConfigKey = "pingone.services.verify.policies.names"
+
+	verifyPolicyRulesConfigKey = "pingone.services.verify.policies.rules"
 )
 
 var (
@@ -59,13 +60,20 @@ var cleanVerifyPoliciesCmd = &cobra.Command{
 			return err
 		}
 
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(verifyPolicyRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
 		cleanConfig := verify.CleanEnvironmentVerifyPoliciesConfig{
-			Environment: clean.CleanEnvironmentConfig{
-				EnvironmentID: viper.GetString(environmentIDParamConfigKey),
-				DryRun:        dryRun,
-				Client:        apiClient.API,
-			},
+			Environment:                environment,
 			BootstrapVerifyPolicyNames: verifyPolicyNames,
+			Rules:                      rules,
 		}
 
 		//results := make([]clean.CleanOutput, 0)
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/clean/services/verify"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	verifyVoicePhraseNames        []string
+	verifyVoicePhraseNamePatterns []string
+)
+
+const (
+	verifyVoicePhrasesCmdName = "verify-voice-phrases"
+
+	verifyVoicePhraseNamesParamName      = "voice-phrase-name"
+	verifyVoicePhraseNamesParamConfigKey = "pingone.services.verify.voice-phrases.names"
+
+	verifyVoicePhraseNamePatternsParamName      = "voice-phrase-name-pattern"
+	verifyVoicePhraseNamePatternsParamConfigKey = "pingone.services.verify.voice-phrases.name-patterns"
+
+	verifyVoicePhraseRulesConfigKey = "pingone.services.verify.voice-phrases.rules"
+)
+
+var (
+	verifyVoicePhraseConfigurationParamMapping = map[string]string{
+		verifyVoicePhraseNamesParamName:        verifyVoicePhraseNamesParamConfigKey,
+		verifyVoicePhraseNamePatternsParamName: verifyVoicePhraseNamePatternsParamConfigKey,
+	}
+)
+
+var cleanVerifyVoicePhrasesCmd = &cobra.Command{
+	Use:   verifyVoicePhrasesCmdName,
+	Short: "Clean unwanted demo and test Verify voice phrases",
+	Long: fmt.Sprintf(`Clean away demo and test Verify voice phrases and their per-language contents, selected by name or name pattern.
+
+	Voice phrases used by the voice settings of a Verify policy are reported as blocked and are not deleted.
+
+	Examples:
+	
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "Test Phrase" --%s
+	pingone-sweep %s --%s 4457a4b7-332e-4e38-9956-09d6e8a19d36 --%s "^Demo .*" --%s
+	
+	`, verifyVoicePhrasesCmdName, environmentIDParamName, verifyVoicePhraseNamesParamName, dryRunParamName, verifyVoicePhrasesCmdName, environmentIDParamName, verifyVoicePhraseNamePatternsParamName, dryRunParamName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.Get()
+
+		dryRun := viper.GetBool(dryRunParamConfigKey)
+		verifyVoicePhraseNames := viper.GetStringSlice(verifyVoicePhraseNamesParamConfigKey)
+		verifyVoicePhraseNamePatterns := viper.GetStringSlice(verifyVoicePhraseNamePatternsParamConfigKey)
+
+		l.Debug().Msgf("Clean Command called for Verify voice phrases.")
+		l.Debug().Msgf("Dry run setting: %t", dryRun)
+		l.Debug().Msgf(`Verify voice phrase names: "%s"`, strings.Join(verifyVoicePhraseNames, `", "`))
+		l.Debug().Msgf(`Verify voice phrase name patterns: "%s"`, strings.Join(verifyVoicePhraseNamePatterns, `", "`))
+
+		patterns, err := compilePatterns(verifyVoicePhraseNamePatterns)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err = initApiClient(cmd.Context(), cmd.Version)
+		if err != nil {
+			return err
+		}
+
+		environment, err := initCleanEnvironmentConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		rules, err := compileRules(verifyVoicePhraseRulesConfigKey)
+		if err != nil {
+			return err
+		}
+
+		cleanConfig := verify.CleanEnvironmentVerifyVoicePhrasesConfig{
+			Environment:                  environment,
+			BootstrapVoicePhraseNames:    verifyVoicePhraseNames,
+			BootstrapVoicePhrasePatterns: patterns,
+			Rules:                        rules,
+		}
+
+		return cleanConfig.Clean(cmd.Context())
+	},
+}
+
+func init() {
+	l := logger.Get()
+
+	cleanVerifyVoicePhrasesCmd.PersistentFlags().StringSliceVar(&verifyVoicePhraseNames, verifyVoicePhraseNamesParamName, []string{}, "The list of Verify voice phrase names to search for to delete.")
+	cleanVerifyVoicePhrasesCmd.PersistentFlags().StringSliceVar(&verifyVoicePhraseNamePatterns, verifyVoicePhraseNamePatternsParamName, []string{}, "The list of regular expressions to match Verify voice phrase names to delete.")
+
+	if err := bindParams(verifyVoicePhraseConfigurationParamMapping, cleanVerifyVoicePhrasesCmd); err != nil {
+		l.Err(err).Msgf("Error binding parameters: %s", err)
+	}
+}
@@ -2,9 +2,13 @@ package clean
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
 	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
@@ -13,39 +17,88 @@ import (
 )
 
 type CleanEnvironmentConfig struct {
-	EnvironmentID string
-	DryRun        bool
-	Client        *pingone.Client
+	EnvironmentID        string
+	DryRun               bool
+	IncludeModified      bool
+	BootstrapWindow      *time.Duration
+	EnvironmentCreatedAt *time.Time
+	Protection           ProtectionConfig
+	Prompter             *Prompter
+	Plan                 *Plan
+	Client               *pingone.Client
 }
 
 type ConfigItem struct {
 	IdentifierToEvaluate string
 	Id                   string
+	Description          *string
 	Default              *bool
 	Enabled              *bool
+	Fingerprint          *string
+	CreatedAt            *time.Time
+	Object               any
+	// BlockedBy returns the reason the item cannot be modified because other configuration depends on it, or nil if it is not blocked.  Only called for selected items.
+	BlockedBy func() (*string, error)
+	// Impact returns a description of the configuration that is affected when the item is modified, or nil if nothing else is affected.  Only called for items that will be acted on.
+	// Any other changes made by the delete or disable function (for example deleting a population's users first) must be described here, as it is
+	// shown when confirming the action and in the item picker.
+	Impact func() (*string, error)
 }
 
 type ConfigItemEval struct {
 	IdentifierListToSearch []string
 	StartsWithStringMatch  bool
 	CaseSensitive          *bool
+	PatternListToSearch    []*regexp.Regexp
+	BootstrapFingerprints  map[string]string
+	Rules                  []Rule
+	Selectors              []ConfigItemSelector
+	ActionLimit            *ActionLimit
+}
+
+// ConfigItemSelector is a service specific selection criterion (for example, an application type), evaluated by the service for each item
+type ConfigItemSelector struct {
+	Name    string
+	Matched bool
+}
+
+// ActionLimit caps the number of items a service acts on (or would act on, in a dry run) in a single run.  A Max of zero or less means no limit.
+type ActionLimit struct {
+	Max   int
+	count int
+}
+
+// Reached returns true when no further actions may be taken
+func (a *ActionLimit) Reached() bool {
+	return a != nil && a.Max > 0 && a.count >= a.Max
+}
+
+func (a *ActionLimit) take() {
+	if a != nil {
+		a.count++
+	}
 }
 
 type CleanOutput struct {
-	ConfigItem     ConfigItem
-	ConfigItemEval ConfigItemEval
-	Action         CleanOutputAction
-	Result         CleanOutputResult
-	Message        *string
+	ConfigItem       ConfigItem
+	ConfigItemEval   ConfigItemEval
+	Action           CleanOutputAction
+	Result           CleanOutputResult
+	FingerprintClass *FingerprintClass
+	MatchedRule      *string
+	Message          *string
 }
 
 type CleanOutputResult string
 
 const (
-	ENUMCLEANOUTPUTRESULT_SUCCESS       CleanOutputResult = "Success"
-	ENUMCLEANOUTPUTRESULT_NOACTION_OK   CleanOutputResult = "No Action (OK)"
-	ENUMCLEANOUTPUTRESULT_NOACTION_WARN CleanOutputResult = "No Action (Warning)"
-	ENUMCLEANOUTPUTRESULT_FAILURE       CleanOutputResult = "Failure"
+	ENUMCLEANOUTPUTRESULT_SUCCESS            CleanOutputResult = "Success"
+	ENUMCLEANOUTPUTRESULT_NOACTION_OK        CleanOutputResult = "No Action (OK)"
+	ENUMCLEANOUTPUTRESULT_NOACTION_WARN      CleanOutputResult = "No Action (Warning)"
+	ENUMCLEANOUTPUTRESULT_NOACTION_PROTECTED CleanOutputResult = "No Action (Protected)"
+	ENUMCLEANOUTPUTRESULT_NOACTION_SKIPPED   CleanOutputResult = "No Action (Skipped)"
+	ENUMCLEANOUTPUTRESULT_NOACTION_BLOCKED   CleanOutputResult = "No Action (Blocked)"
+	ENUMCLEANOUTPUTRESULT_FAILURE            CleanOutputResult = "Failure"
 )
 
 type CleanOutputAction string
@@ -53,6 +106,7 @@ type CleanOutputAction string
 const (
 	ENUMCLEANOUTPUTACTION_DELETE  CleanOutputAction = "Delete"
 	ENUMCLEANOUTPUTRESULT_DISABLE CleanOutputAction = "Disable"
+	ENUMCLEANOUTPUTACTION_RESET   CleanOutputAction = "Reset"
 )
 
 func BillOfMaterialsHasService(ctx context.Context, configKey string, env CleanEnvironmentConfig, productType management.EnumProductType) (bool, error) {
@@ -88,6 +142,91 @@ func BillOfMaterialsHasService(ctx context.Context, configKey string, env CleanE
 	return false, nil
 }
 
+func ReadEnvironmentCreatedAt(ctx context.Context, env CleanEnvironmentConfig) (*time.Time, error) {
+	l := logger.Get()
+
+	var response *management.Environment
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return env.Client.ManagementAPIClient.EnvironmentsApi.ReadOneEnvironment(ctx, env.EnvironmentID).Execute()
+		},
+		"ReadOneEnvironment",
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response == nil {
+		return nil, fmt.Errorf("No environment found - the API responded with no data")
+	}
+
+	createdAt, err := ParseTimestamp(response.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if createdAt == nil {
+		return nil, fmt.Errorf("Cannot determine the environment creation time - the API responded with no data")
+	}
+
+	l.Debug().Msgf(`Environment "%s" created at %s`, env.EnvironmentID, createdAt.Format(time.RFC3339))
+
+	return createdAt, nil
+}
+
+// ParseTimestamp parses the RFC3339 timestamps returned as strings by some of the PingOne APIs.  A nil or empty value returns nil.
+func ParseTimestamp(v *string) (*time.Time, error) {
+	if v == nil || *v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, *v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// CreationTimes reads the creation time of each item in the named collection of a raw list response, keyed by ID, for the SDK models that don't include it
+func CreationTimes(r *http.Response, collection string) (map[string]*time.Time, error) {
+	createdAt := make(map[string]*time.Time)
+
+	if r == nil || r.Body == nil {
+		return createdAt, nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		Embedded map[string][]struct {
+			Id        string  `json:"id"`
+			CreatedAt *string `json:"createdAt,omitempty"`
+		} `json:"_embedded"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	for _, item := range raw.Embedded[collection] {
+		t, err := ParseTimestamp(item.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		createdAt[item.Id] = t
+	}
+
+	return createdAt, nil
+}
+
 func ReadAllConfig(ctx context.Context, configKey string, env CleanEnvironmentConfig, readAllSdkFunction sdk.SDKInterfaceFunc, targetObject any) error {
 
 	err := sdk.ParseResponse(
@@ -127,7 +266,276 @@ func TryCleanConfig(ctx context.Context, configKey string, env CleanEnvironmentC
 		sdkActionFunc = disableSdkFunction
 	}
 
+	if configItem.Fingerprint != nil {
+		l.Debug().Msgf(`[%s] Fingerprint for "%s": %s`, configKey, configItem.IdentifierToEvaluate, *configItem.Fingerprint)
+	}
+
 	l.Debug().Msgf(`[%s] Looping configured list of identifiers for "%s" for action %s..`, configKey, configItem.IdentifierToEvaluate, debugAction)
+	matchedIdentifier := matchIdentifier(configItem, configItemEval)
+
+	fingerprintClass, matchedIdentifier := classifyFingerprint(configItem, configItemEval, matchedIdentifier)
+
+	var matchedRule *string
+	if matchedIdentifier != nil {
+		l.Debug().Msgf(`[%s] Found "%s"`, configKey, *matchedIdentifier)
+	} else {
+		var err error
+		matchedRule, err = matchRules(configItem, configItemEval)
+		if err != nil {
+			return fmt.Errorf("[%s] %w", configKey, err)
+		}
+
+		if matchedRule == nil {
+			return nil
+		}
+
+		l.Debug().Msgf(`[%s] "%s" matched rule "%s"`, configKey, configItem.IdentifierToEvaluate, *matchedRule)
+	}
+
+	output := CleanOutput{
+		ConfigItem:       configItem,
+		ConfigItemEval:   configItemEval,
+		Action:           debugAction,
+		FingerprintClass: fingerprintClass,
+		MatchedRule:      matchedRule,
+	}
+
+	if fingerprintClass != nil {
+		l.Debug().Msgf(`[%s] "%s" classified as %s against bootstrap item "%s"`, configKey, configItem.IdentifierToEvaluate, *fingerprintClass, *matchedIdentifier)
+	}
+
+	if message := env.Protection.protectedReason(configItem); message != nil {
+		l.Info().Msgf(`[%s] No action taken: %s`, configKey, *message)
+
+		output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_PROTECTED
+		output.Message = message
+		handleOutput(configKey, output, env.DryRun)
+
+		return nil
+	}
+
+	if env.BootstrapWindow != nil && env.EnvironmentCreatedAt != nil {
+
+		// Without a creation time the item cannot be confirmed as bootstrap configuration, so it is held back
+		if configItem.CreatedAt == nil {
+
+			message := fmt.Sprintf(`the creation time of "%s" is not available, so it cannot be confirmed to be within the bootstrap window`, configItem.IdentifierToEvaluate)
+			l.Warn().Msgf(`[%s] No action taken: %s`, configKey, message)
+
+			output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_WARN
+			output.Message = &message
+			handleOutput(configKey, output, env.DryRun)
+
+			return nil
+		}
+
+		if !withinBootstrapWindow(*configItem.CreatedAt, *env.EnvironmentCreatedAt, *env.BootstrapWindow) {
+
+			message := fmt.Sprintf(`"%s" was created at %s, outside of the bootstrap window of %s from environment creation`, configItem.IdentifierToEvaluate, configItem.CreatedAt.Format(time.RFC3339), env.BootstrapWindow.String())
+			l.Info().Msgf(`[%s] No action taken: %s`, configKey, message)
+
+			output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_OK
+			output.Message = &message
+			handleOutput(configKey, output, env.DryRun)
+
+			return nil
+		}
+	}
+
+	if configItem.Default != nil && *configItem.Default {
+
+		message := fmt.Sprintf(`"%s" is set as the environment default and cannot be removed`, configItem.IdentifierToEvaluate)
+		l.Warn().Msgf(`[%s] No action taken: %s`, configKey, message)
+
+		output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_WARN
+		output.Message = &message
+		handleOutput(configKey, output, env.DryRun)
+
+		return nil
+	}
+
+	if configItem.BlockedBy != nil {
+		reason, err := configItem.BlockedBy()
+		if err != nil {
+			return err
+		}
+
+		if reason != nil {
+			message := fmt.Sprintf(`"%s" is blocked: %s`, configItem.IdentifierToEvaluate, *reason)
+			l.Warn().Msgf(`[%s] No action taken: %s`, configKey, message)
+
+			output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_BLOCKED
+			output.Message = &message
+			handleOutput(configKey, output, env.DryRun)
+
+			return nil
+		}
+	}
+
+	if fingerprintClass != nil && *fingerprintClass == ENUMFINGERPRINTCLASS_MODIFIED && !env.IncludeModified {
+
+		message := fmt.Sprintf(`"%s" has been modified from the pristine bootstrap configuration and modified items are not included`, configItem.IdentifierToEvaluate)
+		l.Warn().Msgf(`[%s] No action taken: %s`, configKey, message)
+
+		output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_WARN
+		output.Message = &message
+		handleOutput(configKey, output, env.DryRun)
+
+		return nil
+	}
+
+	if configItem.Enabled != nil && !*configItem.Enabled && disableSdkFunction != nil {
+		message := fmt.Sprintf(`"%s" is already disabled`, configItem.IdentifierToEvaluate)
+		l.Info().Msgf(`[%s] No action taken: %s`, configKey, message)
+
+		output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_OK
+		output.Message = &message
+		handleOutput(configKey, output, env.DryRun)
+
+		return nil
+	}
+
+	if configItemEval.ActionLimit.Reached() {
+		message := fmt.Sprintf(`the limit of %d actions has been reached, "%s" will not be modified`, configItemEval.ActionLimit.Max, configItem.IdentifierToEvaluate)
+		l.Warn().Msgf(`[%s] No action taken: %s`, configKey, message)
+
+		output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_SKIPPED
+		output.Message = &message
+		handleOutput(configKey, output, env.DryRun)
+
+		return nil
+	}
+
+	if configItem.Impact != nil {
+		impact, err := configItem.Impact()
+		if err != nil {
+			return err
+		}
+
+		if impact != nil {
+			l.Warn().Msgf(`[%s] %s action for "%s" affects %s`, configKey, debugAction, configItem.IdentifierToEvaluate, *impact)
+			output.Message = impact
+		}
+	}
+
+	if env.Plan != nil {
+		l.Debug().Msgf(`[%s] Adding %s action for "%s" to the plan`, configKey, debugAction, configItem.IdentifierToEvaluate)
+		configItemEval.ActionLimit.take()
+		env.Plan.add(configKey, env, output, sdkActionFunc)
+
+		return nil
+	}
+
+	if env.Prompter != nil && !env.DryRun {
+		confirmed, err := env.Prompter.confirm(configKey, configItem, debugAction, output.Message)
+		if err != nil {
+			return err
+		}
+
+		if !confirmed {
+			message := fmt.Sprintf(`%s action for "%s" was declined`, debugAction, configItem.IdentifierToEvaluate)
+			l.Info().Msgf(`[%s] No action taken: %s`, configKey, message)
+
+			output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_SKIPPED
+			output.Message = &message
+			handleOutput(configKey, output, env.DryRun)
+
+			return nil
+		}
+	}
+
+	configItemEval.ActionLimit.take()
+
+	return executeAction(ctx, configKey, env, output, sdkActionFunc)
+}
+
+// ReportConfig lists a configuration item without acting on it, for services that audit configuration and only act when asked to.  Items selected
+// by the evaluation criteria are reported as needing review, with the given message.
+func ReportConfig(configKey string, env CleanEnvironmentConfig, configItem ConfigItem, configItemEval ConfigItemEval, action CleanOutputAction, message string) error {
+	l := logger.Get()
+
+	output := CleanOutput{
+		ConfigItem:     configItem,
+		ConfigItemEval: configItemEval,
+		Action:         action,
+		Result:         ENUMCLEANOUTPUTRESULT_NOACTION_OK,
+	}
+
+	matched := matchIdentifier(configItem, configItemEval)
+	if matched == nil {
+		var err error
+		matched, err = matchRules(configItem, configItemEval)
+		if err != nil {
+			return fmt.Errorf("[%s] %w", configKey, err)
+		}
+	}
+
+	if matched == nil {
+		handleOutput(configKey, output, env.DryRun)
+		return nil
+	}
+
+	output.MatchedRule = matched
+
+	if protected := env.Protection.protectedReason(configItem); protected != nil {
+		l.Info().Msgf(`[%s] No action taken: %s`, configKey, *protected)
+
+		output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_PROTECTED
+		output.Message = protected
+		handleOutput(configKey, output, env.DryRun)
+
+		return nil
+	}
+
+	l.Warn().Msgf(`[%s] "%s" needs review: %s`, configKey, configItem.IdentifierToEvaluate, message)
+
+	output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_WARN
+	output.Message = &message
+	handleOutput(configKey, output, env.DryRun)
+
+	return nil
+}
+
+func executeAction(ctx context.Context, configKey string, env CleanEnvironmentConfig, output CleanOutput, sdkActionFunc sdk.SDKInterfaceFunc) error {
+	l := logger.Get()
+
+	if !env.DryRun {
+
+		err := sdk.ParseResponse(
+			ctx,
+			sdkActionFunc,
+			fmt.Sprintf("[%s]-%s", configKey, output.Action),
+			sdk.DefaultCreateReadRetryable,
+			nil,
+		)
+
+		if err != nil {
+			return err
+		}
+		l.Info().Msgf(`[%s] %s action completed for "%s"`, configKey, output.Action, output.ConfigItem.IdentifierToEvaluate)
+	} else {
+		l.Warn().Msgf(`[%s] Dry run: %s action "%s" with ID "%s"`, configKey, output.Action, output.ConfigItem.IdentifierToEvaluate, output.ConfigItem.Id)
+	}
+
+	output.Result = ENUMCLEANOUTPUTRESULT_SUCCESS
+	handleOutput(configKey, output, env.DryRun)
+
+	return nil
+}
+
+func withinBootstrapWindow(itemCreatedAt, environmentCreatedAt time.Time, window time.Duration) bool {
+	diff := itemCreatedAt.Sub(environmentCreatedAt)
+
+	// Allow for small clock differences between services either side of the environment creation
+	if diff < 0 {
+		diff = -diff
+	}
+
+	return diff <= window
+}
+
+func matchIdentifier(configItem ConfigItem, configItemEval ConfigItemEval) *string {
+
 	for _, identifierToSearch := range configItemEval.IdentifierListToSearch {
 
 		var eqExprResult bool
@@ -138,73 +546,45 @@ func TryCleanConfig(ctx context.Context, configKey string, env CleanEnvironmentC
 		}
 
 		if eqExprResult {
-			l.Debug().Msgf(`[%s] Found "%s"`, configKey, identifierToSearch)
-
-			if configItem.Default != nil && *configItem.Default {
-
-				message := fmt.Sprintf(`"%s" is set as the environment default and cannot be removed`, configItem.IdentifierToEvaluate)
-				l.Warn().Msgf(`[%s] No action taken: %s`, configKey, message)
-
-				handleOutput(configKey, CleanOutput{
-					ConfigItem:     configItem,
-					ConfigItemEval: configItemEval,
-					Action:         debugAction,
-					Result:         ENUMCLEANOUTPUTRESULT_NOACTION_WARN,
-					Message:        &message,
-				},
-					env.DryRun,
-				)
-
-				break
-			}
-
-			if configItem.Enabled != nil && !*configItem.Enabled && disableSdkFunction != nil {
-				message := fmt.Sprintf(`"%s" is already disabled`, configItem.IdentifierToEvaluate)
-				l.Info().Msgf(`[%s] No action taken: %s`, configKey, message)
-
-				handleOutput(configKey, CleanOutput{
-					ConfigItem:     configItem,
-					ConfigItemEval: configItemEval,
-					Action:         debugAction,
-					Result:         ENUMCLEANOUTPUTRESULT_NOACTION_OK,
-					Message:        &message,
-				},
-					env.DryRun,
-				)
-
-				break
-			}
-
-			if !env.DryRun {
-
-				err := sdk.ParseResponse(
-					ctx,
-					sdkActionFunc,
-					fmt.Sprintf("[%s]-%s", configKey, debugAction),
-					sdk.DefaultCreateReadRetryable,
-					nil,
-				)
-
-				if err != nil {
-					return err
-				}
-				l.Info().Msgf(`[%s] %s action completed for "%s"`, configKey, debugAction, configItem.IdentifierToEvaluate)
-			} else {
-				l.Warn().Msgf(`[%s] Dry run: %s action "%s" with ID "%s"`, configKey, debugAction, configItem.IdentifierToEvaluate, configItem.Id)
-			}
-
-			handleOutput(configKey, CleanOutput{
-				ConfigItem:     configItem,
-				ConfigItemEval: configItemEval,
-				Action:         debugAction,
-				Result:         ENUMCLEANOUTPUTRESULT_SUCCESS,
-			},
-				env.DryRun,
-			)
-
-			break
+			matchedIdentifier := identifierToSearch
+			return &matchedIdentifier
+		}
+	}
+
+	for _, pattern := range configItemEval.PatternListToSearch {
+		if pattern.MatchString(configItem.IdentifierToEvaluate) {
+			matchedIdentifier := pattern.String()
+			return &matchedIdentifier
 		}
 	}
 
 	return nil
 }
+
+func matchRules(configItem ConfigItem, configItemEval ConfigItemEval) (*string, error) {
+
+	for _, selector := range configItemEval.Selectors {
+		if selector.Matched {
+			selectorName := selector.Name
+			return &selectorName, nil
+		}
+	}
+
+	if configItem.Object == nil {
+		return nil, nil
+	}
+
+	for _, rule := range configItemEval.Rules {
+		match, err := rule.Evaluate(configItem.Object)
+		if err != nil {
+			return nil, err
+		}
+
+		if match {
+			ruleName := rule.Name
+			return &ruleName, nil
+		}
+	}
+
+	return nil, nil
+}
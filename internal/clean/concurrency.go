@@ -0,0 +1,57 @@
+package clean
+
+import (
+	"context"
+	"sync"
+)
+
+// ForEachConcurrently calls f for each item, with at most the given number of calls in progress at once.  Requests made by f share the API request rate limit.
+// After the first error, no further calls are started and that error is returned once the calls in progress have finished.
+func ForEachConcurrently[T any](ctx context.Context, concurrency int, items []T, f func(context.Context, T) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	sem := make(chan struct{}, concurrency)
+
+	for _, item := range items {
+		item := item
+
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := f(ctx, item); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
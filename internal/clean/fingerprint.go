@@ -0,0 +1,136 @@
+package clean
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+type FingerprintClass string
+
+const (
+	ENUMFINGERPRINTCLASS_PRISTINE         FingerprintClass = "Pristine"
+	ENUMFINGERPRINTCLASS_MODIFIED         FingerprintClass = "Modified"
+	ENUMFINGERPRINTCLASS_RENAMED_PRISTINE FingerprintClass = "Renamed Pristine"
+)
+
+var (
+	// Keys that differ between environments (or over time) for otherwise identical configuration, removed at every level of the object before hashing
+	fingerprintVolatileKeys = []string{
+		"_links",
+		"createdAt",
+		"environment",
+		"id",
+		"updatedAt",
+	}
+)
+
+// Fingerprint returns a stable hash of the settings of a configuration item.  Identity and metadata keys are removed at every level of the object, and
+// the optional ignoreKeys are removed from the top level only (for example the name and description, so that renamed items can still be recognised).
+func Fingerprint(v any, ignoreKeys ...string) (string, error) {
+
+	objectBytes, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	var object any
+	if err := json.Unmarshal(objectBytes, &object); err != nil {
+		return "", err
+	}
+
+	if objectMap, ok := object.(map[string]any); ok {
+		for _, key := range ignoreKeys {
+			delete(objectMap, key)
+		}
+	}
+
+	object = removeVolatileKeys(object)
+
+	// encoding/json sorts map keys, so the re-marshalled object is canonical
+	canonicalBytes, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(canonicalBytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func removeVolatileKeys(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for _, key := range fingerprintVolatileKeys {
+			delete(t, key)
+		}
+		for k, child := range t {
+			t[k] = removeVolatileKeys(child)
+		}
+		return t
+	case []any:
+		for i, child := range t {
+			t[i] = removeVolatileKeys(child)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// classifyFingerprint classifies a fingerprinted item against the stored bootstrap fingerprints.  An item matched by name that has no stored
+// fingerprint is not classified, and is treated as any other name matched item.
+func classifyFingerprint(configItem ConfigItem, configItemEval ConfigItemEval, matchedIdentifier *string) (*FingerprintClass, *string) {
+
+	if configItem.Fingerprint == nil {
+		return nil, matchedIdentifier
+	}
+
+	if matchedIdentifier != nil {
+		bootstrapFingerprint, ok := bootstrapFingerprint(configItemEval.BootstrapFingerprints, *matchedIdentifier)
+		if !ok {
+			return nil, matchedIdentifier
+		}
+
+		class := ENUMFINGERPRINTCLASS_MODIFIED
+		if bootstrapFingerprint == *configItem.Fingerprint {
+			class = ENUMFINGERPRINTCLASS_PRISTINE
+		}
+
+		return &class, matchedIdentifier
+	}
+
+	// Sort so that the result is deterministic if more than one bootstrap item shares a fingerprint
+	bootstrapNames := make([]string, 0, len(configItemEval.BootstrapFingerprints))
+	for name := range configItemEval.BootstrapFingerprints {
+		bootstrapNames = append(bootstrapNames, name)
+	}
+	sort.Strings(bootstrapNames)
+
+	for _, name := range bootstrapNames {
+		if configItemEval.BootstrapFingerprints[name] == *configItem.Fingerprint {
+			class := ENUMFINGERPRINTCLASS_RENAMED_PRISTINE
+			bootstrapName := name
+			return &class, &bootstrapName
+		}
+	}
+
+	return nil, nil
+}
+
+// bootstrapFingerprint looks up the stored fingerprint for a bootstrap item name.  Names are compared case insensitively, as configuration file map
+// keys are lower cased when they are loaded.
+func bootstrapFingerprint(fingerprints map[string]string, name string) (string, bool) {
+	if fingerprint, ok := fingerprints[name]; ok {
+		return fingerprint, true
+	}
+
+	for k, fingerprint := range fingerprints {
+		if strings.EqualFold(k, name) {
+			return fingerprint, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,196 @@
+package clean
+
+import (
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+
+	base := map[string]any{
+		"name":      "Standard",
+		"id":        "1",
+		"createdAt": "2024-01-01T00:00:00Z",
+		"length":    map[string]any{"min": 8, "max": 255},
+		"history":   []any{map[string]any{"id": "a", "count": 6}},
+	}
+
+	baseFingerprint, err := Fingerprint(base, "name")
+	if err != nil {
+		t.Fatalf("Fingerprint returned an error: %s", err)
+	}
+
+	tests := []struct {
+		name       string
+		object     any
+		ignoreKeys []string
+		same       bool
+	}{
+		{
+			name: "volatile keys are ignored at every level",
+			object: map[string]any{
+				"name":      "Standard",
+				"id":        "2",
+				"createdAt": "2025-06-01T00:00:00Z",
+				"updatedAt": "2025-06-02T00:00:00Z",
+				"length":    map[string]any{"max": 255, "min": 8},
+				"history":   []any{map[string]any{"id": "b", "count": 6}},
+			},
+			ignoreKeys: []string{"name"},
+			same:       true,
+		},
+		{
+			name: "ignored top level keys allow renamed items to match",
+			object: map[string]any{
+				"name":    "Renamed",
+				"length":  map[string]any{"min": 8, "max": 255},
+				"history": []any{map[string]any{"count": 6}},
+			},
+			ignoreKeys: []string{"name"},
+			same:       true,
+		},
+		{
+			name: "top level keys are not ignored unless asked",
+			object: map[string]any{
+				"name":    "Renamed",
+				"length":  map[string]any{"min": 8, "max": 255},
+				"history": []any{map[string]any{"count": 6}},
+			},
+			same: false,
+		},
+		{
+			name: "changed settings change the fingerprint",
+			object: map[string]any{
+				"name":    "Standard",
+				"length":  map[string]any{"min": 12, "max": 255},
+				"history": []any{map[string]any{"count": 6}},
+			},
+			ignoreKeys: []string{"name"},
+			same:       false,
+		},
+		{
+			name: "changed nested list items change the fingerprint",
+			object: map[string]any{
+				"name":    "Standard",
+				"length":  map[string]any{"min": 8, "max": 255},
+				"history": []any{map[string]any{"count": 3}},
+			},
+			ignoreKeys: []string{"name"},
+			same:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fingerprint, err := Fingerprint(tt.object, tt.ignoreKeys...)
+			if err != nil {
+				t.Fatalf("Fingerprint returned an error: %s", err)
+			}
+
+			if (fingerprint == baseFingerprint) != tt.same {
+				t.Errorf("expected same fingerprint %t, got %s and %s", tt.same, fingerprint, baseFingerprint)
+			}
+		})
+	}
+}
+
+func TestClassifyFingerprint(t *testing.T) {
+
+	fingerprints := map[string]string{
+		// Configuration file map keys are lower cased when loaded
+		"standard": "aaa",
+		"basic":    "bbb",
+	}
+
+	tests := []struct {
+		name              string
+		fingerprint       *string
+		fingerprints      map[string]string
+		matchedIdentifier *string
+		expectedClass     *FingerprintClass
+		expectedMatch     *string
+	}{
+		{
+			name:              "items without a fingerprint are not classified",
+			fingerprint:       nil,
+			fingerprints:      fingerprints,
+			matchedIdentifier: stringPtr("Standard"),
+			expectedClass:     nil,
+			expectedMatch:     stringPtr("Standard"),
+		},
+		{
+			name:              "pristine item matched by name, case insensitively",
+			fingerprint:       stringPtr("aaa"),
+			fingerprints:      fingerprints,
+			matchedIdentifier: stringPtr("Standard"),
+			expectedClass:     classPtr(ENUMFINGERPRINTCLASS_PRISTINE),
+			expectedMatch:     stringPtr("Standard"),
+		},
+		{
+			name:              "modified item matched by name",
+			fingerprint:       stringPtr("zzz"),
+			fingerprints:      fingerprints,
+			matchedIdentifier: stringPtr("Standard"),
+			expectedClass:     classPtr(ENUMFINGERPRINTCLASS_MODIFIED),
+			expectedMatch:     stringPtr("Standard"),
+		},
+		{
+			name:              "item matched by name without a stored fingerprint",
+			fingerprint:       stringPtr("aaa"),
+			fingerprints:      map[string]string{},
+			matchedIdentifier: stringPtr("Standard"),
+			expectedClass:     nil,
+			expectedMatch:     stringPtr("Standard"),
+		},
+		{
+			name:              "renamed pristine item",
+			fingerprint:       stringPtr("bbb"),
+			fingerprints:      fingerprints,
+			matchedIdentifier: nil,
+			expectedClass:     classPtr(ENUMFINGERPRINTCLASS_RENAMED_PRISTINE),
+			expectedMatch:     stringPtr("basic"),
+		},
+		{
+			name:              "unmatched item with an unknown fingerprint",
+			fingerprint:       stringPtr("zzz"),
+			fingerprints:      fingerprints,
+			matchedIdentifier: nil,
+			expectedClass:     nil,
+			expectedMatch:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			class, match := classifyFingerprint(
+				ConfigItem{Fingerprint: tt.fingerprint},
+				ConfigItemEval{BootstrapFingerprints: tt.fingerprints},
+				tt.matchedIdentifier,
+			)
+
+			if (class == nil) != (tt.expectedClass == nil) || (class != nil && *class != *tt.expectedClass) {
+				t.Errorf("expected class %v, got %v", deref(tt.expectedClass), deref(class))
+			}
+
+			if (match == nil) != (tt.expectedMatch == nil) || (match != nil && *match != *tt.expectedMatch) {
+				t.Errorf("expected match %v, got %v", deref(tt.expectedMatch), deref(match))
+			}
+		})
+	}
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+func classPtr(v FingerprintClass) *FingerprintClass {
+	return &v
+}
+
+func deref[T any](v *T) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -0,0 +1,105 @@
+package clean
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+const (
+	interactivePreviewMaxLines = 20
+)
+
+// Prompter asks the user to confirm each action before it is taken.  The answers "all" and "quit" apply to every later prompt.
+type Prompter struct {
+	in   *bufio.Reader
+	out  io.Writer
+	all  bool
+	quit bool
+}
+
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+func (p *Prompter) confirm(configKey string, configItem ConfigItem, action CleanOutputAction, impact *string) (bool, error) {
+	if p.quit {
+		return false, nil
+	}
+
+	if p.all {
+		return true, nil
+	}
+
+	configKeyFormat := color.New(color.FgBlue, color.Bold).SprintFunc()
+
+	fmt.Fprintf(p.out, "\n%s - %s\n", configKeyFormat(configKey), configItem.IdentifierToEvaluate)
+	fmt.Fprintf(p.out, "  ID:      %s\n", configItem.Id)
+	fmt.Fprintf(p.out, "  Action:  %s\n", action)
+	fmt.Fprintf(p.out, "  Default: %s\n", formatOptionalBool(configItem.Default))
+	fmt.Fprintf(p.out, "  Enabled: %s\n", formatOptionalBool(configItem.Enabled))
+
+	if impact != nil {
+		fmt.Fprintf(p.out, "  Affects: %s\n", *impact)
+	}
+
+	if preview := jsonPreview(configItem.Object, interactivePreviewMaxLines); preview != "" {
+		fmt.Fprintf(p.out, "%s\n", preview)
+	}
+
+	for {
+		fmt.Fprintf(p.out, "%s action? [y]es / [n]o / [a]ll / [q]uit: ", action)
+
+		answer, err := p.in.ReadString('\n')
+		if err != nil && (err != io.EOF || answer == "") {
+			// Treat a closed input as "quit", so that nothing further is changed without confirmation
+			p.quit = true
+			return false, nil
+		}
+
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no", "":
+			return false, nil
+		case "a", "all":
+			p.all = true
+			return true, nil
+		case "q", "quit":
+			p.quit = true
+			return false, nil
+		}
+	}
+}
+
+func formatOptionalBool(v *bool) string {
+	if v == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf("%t", *v)
+}
+
+func jsonPreview(object any, maxLines int) string {
+	if object == nil {
+		return ""
+	}
+
+	objectBytes, err := json.MarshalIndent(object, "  ", "  ")
+	if err != nil {
+		return ""
+	}
+
+	lines := strings.Split("  "+string(objectBytes), "\n")
+	if len(lines) > maxLines {
+		lines = append(lines[:maxLines], fmt.Sprintf("  ... (%d more lines)", len(lines)-maxLines))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -28,6 +28,14 @@ func handleOutput(configKey string, output CleanOutput, dryRun bool) {
 
 	printString = fmt.Sprintf("%s with action %s", printString, output.Action)
 
+	if output.MatchedRule != nil {
+		printString = fmt.Sprintf("%s (rule %s)", printString, *output.MatchedRule)
+	}
+
+	if output.FingerprintClass != nil {
+		printString = fmt.Sprintf("%s [%s]", printString, *output.FingerprintClass)
+	}
+
 	switch output.Result {
 	case ENUMCLEANOUTPUTRESULT_SUCCESS:
 		printString = fmt.Sprintf("%s - %s", printString, color.GreenString("Success"))
@@ -35,6 +43,12 @@ func handleOutput(configKey string, output CleanOutput, dryRun bool) {
 		printString = fmt.Sprintf("%s - %s", printString, color.GreenString("No action taken"))
 	case ENUMCLEANOUTPUTRESULT_NOACTION_WARN:
 		printString = fmt.Sprintf("%s - %s", printString, color.YellowString("No action taken (needs review)"))
+	case ENUMCLEANOUTPUTRESULT_NOACTION_PROTECTED:
+		printString = fmt.Sprintf("%s - %s", printString, color.CyanString("No action taken (protected)"))
+	case ENUMCLEANOUTPUTRESULT_NOACTION_SKIPPED:
+		printString = fmt.Sprintf("%s - %s", printString, color.CyanString("No action taken (skipped)"))
+	case ENUMCLEANOUTPUTRESULT_NOACTION_BLOCKED:
+		printString = fmt.Sprintf("%s - %s", printString, color.YellowString("No action taken (blocked)"))
 	case ENUMCLEANOUTPUTRESULT_FAILURE:
 		printString = fmt.Sprintf("%s - %s", printString, color.RedString("Request Failure"))
 	}
@@ -0,0 +1,164 @@
+package clean
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const (
+	pickerHeaderLines   = 4
+	pickerDefaultWidth  = 120
+	pickerDefaultHeight = 24
+)
+
+// Pick shows a full-screen list of the planned items, in which the user can tick and untick items before they are applied.  The other
+// configuration affected by the item under the cursor (for example users deleted with a population) is shown above the list.
+// Returns false if the user cancelled the selection.
+func (p *Plan) Pick(in *os.File, out io.Writer) (bool, error) {
+
+	if len(p.Items) == 0 {
+		return false, nil
+	}
+
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return false, fmt.Errorf("The item picker requires an interactive terminal")
+	}
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return false, fmt.Errorf("Cannot initialise the terminal for the item picker: %w", err)
+	}
+	defer term.Restore(fd, oldState) //nolint:errcheck // best effort to return the terminal to its original state
+
+	// Switch to the alternate screen and hide the cursor, reversing both on exit
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	cursor, offset := 0, 0
+	buf := make([]byte, 8)
+
+	for {
+		width, height, err := term.GetSize(fd)
+		if err != nil {
+			width, height = pickerDefaultWidth, pickerDefaultHeight
+		}
+
+		visible := height - pickerHeaderLines
+		if visible < 1 {
+			visible = 1
+		}
+
+		if cursor < offset {
+			offset = cursor
+		}
+		if cursor >= offset+visible {
+			offset = cursor - visible + 1
+		}
+
+		p.render(out, cursor, offset, visible, width)
+
+		n, err := in.Read(buf)
+		if err != nil {
+			return false, err
+		}
+
+		switch string(buf[:n]) {
+		case "\x1b[A", "k":
+			if cursor > 0 {
+				cursor--
+			}
+		case "\x1b[B", "j":
+			if cursor < len(p.Items)-1 {
+				cursor++
+			}
+		case "\x1b[5~":
+			cursor -= visible
+			if cursor < 0 {
+				cursor = 0
+			}
+		case "\x1b[6~":
+			cursor += visible
+			if cursor > len(p.Items)-1 {
+				cursor = len(p.Items) - 1
+			}
+		case " ", "x":
+			p.Items[cursor].Selected = !p.Items[cursor].Selected
+		case "a":
+			p.selectAll(true)
+		case "n":
+			p.selectAll(false)
+		case "\r", "\n":
+			return true, nil
+		case "q", "\x1b", "\x03":
+			return false, nil
+		}
+	}
+}
+
+func (p *Plan) selectAll(selected bool) {
+	for _, item := range p.Items {
+		item.Selected = selected
+	}
+}
+
+func (p *Plan) render(out io.Writer, cursor, offset, visible, width int) {
+	var sb strings.Builder
+
+	selectedCount := 0
+	for _, item := range p.Items {
+		if item.Selected {
+			selectedCount++
+		}
+	}
+
+	// Raw mode doesn't translate line feeds, so each line ends with an explicit carriage return
+	sb.WriteString("\x1b[H\x1b[2J")
+	sb.WriteString(truncate(fmt.Sprintf("pingone-sweep - %d of %d items selected", selectedCount, len(p.Items)), width) + "\r\n")
+	sb.WriteString(truncate("up/down: move  space: tick/untick  a: all  n: none  enter: apply  q: cancel", width) + "\r\n")
+	if impact := p.Items[cursor].Output.Message; impact != nil {
+		sb.WriteString(truncate(fmt.Sprintf("Affects: %s", *impact), width))
+	}
+	sb.WriteString("\r\n")
+	sb.WriteString("\r\n")
+
+	for i := offset; i < len(p.Items) && i < offset+visible; i++ {
+		item := p.Items[i]
+
+		pointer := " "
+		if i == cursor {
+			pointer = ">"
+		}
+
+		tick := " "
+		if item.Selected {
+			tick = "x"
+		}
+
+		line := fmt.Sprintf("%s [%s] %s - %s (%s) %s", pointer, tick, item.ConfigKey, item.Output.ConfigItem.IdentifierToEvaluate, item.Output.ConfigItem.Id, item.Output.Action)
+		if item.Output.Message != nil {
+			line += " - affects other configuration"
+		}
+
+		line = truncate(line, width)
+		if i == cursor {
+			line = "\x1b[7m" + line + "\x1b[0m"
+		}
+
+		sb.WriteString(line + "\r\n")
+	}
+
+	fmt.Fprint(out, sb.String())
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if width > 0 && len(runes) > width {
+		return string(runes[:width])
+	}
+	return s
+}
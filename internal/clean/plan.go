@@ -0,0 +1,65 @@
+package clean
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+// Plan collects the actions that would be taken across services, so that they can be reviewed and selected before any are applied
+type Plan struct {
+	Items []*PlanItem
+}
+
+type PlanItem struct {
+	ConfigKey     string
+	Output        CleanOutput
+	Selected      bool
+	env           CleanEnvironmentConfig
+	sdkActionFunc sdk.SDKInterfaceFunc
+}
+
+func NewPlan() *Plan {
+	return &Plan{
+		Items: make([]*PlanItem, 0),
+	}
+}
+
+func (p *Plan) add(configKey string, env CleanEnvironmentConfig, output CleanOutput, sdkActionFunc sdk.SDKInterfaceFunc) {
+	p.Items = append(p.Items, &PlanItem{
+		ConfigKey:     configKey,
+		Output:        output,
+		Selected:      true,
+		env:           env,
+		sdkActionFunc: sdkActionFunc,
+	})
+}
+
+// Apply takes the action for each selected item in the plan.  Items that were not selected are reported as skipped.
+func (p *Plan) Apply(ctx context.Context) error {
+	l := logger.Get()
+
+	l.Debug().Msgf("Applying %d planned actions..", len(p.Items))
+
+	for _, item := range p.Items {
+		if !item.Selected {
+			message := fmt.Sprintf(`"%s" was not selected`, item.Output.ConfigItem.IdentifierToEvaluate)
+			l.Info().Msgf(`[%s] No action taken: %s`, item.ConfigKey, message)
+
+			output := item.Output
+			output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_SKIPPED
+			output.Message = &message
+			handleOutput(item.ConfigKey, output, item.env.DryRun)
+
+			continue
+		}
+
+		if err := executeAction(ctx, item.ConfigKey, item.env, item.Output, item.sdkActionFunc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package clean
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+const (
+	DefaultProtectionMarker = "[sweep:keep]"
+)
+
+var (
+	// The PingOne-owned applications, which are never modified
+	systemApplicationTypes = []management.EnumApplicationType{
+		management.ENUMAPPLICATIONTYPE_PING_ONE_ADMIN_CONSOLE,
+		management.ENUMAPPLICATIONTYPE_PING_ONE_PORTAL,
+		management.ENUMAPPLICATIONTYPE_PING_ONE_SELF_SERVICE,
+	}
+)
+
+type ProtectionConfig struct {
+	// A marker that protects an item when found in its description (or name, where the item has no description)
+	Marker string
+	IDs    []string
+	Names  []string
+}
+
+// ReadProtectedIDsFile reads a list of protected configuration IDs from a file, one per line.  Blank lines and lines starting with # are ignored.
+func ReadProtectedIDsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read the protected ID file: %w", err)
+	}
+	defer file.Close()
+
+	ids := make([]string, 0)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ids = append(ids, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Cannot read the protected ID file: %w", err)
+	}
+
+	return ids, nil
+}
+
+// IsProtected returns true if the item is protected, for services that check items they change as part of another item's action
+func (p ProtectionConfig) IsProtected(configItem ConfigItem) bool {
+	return p.protectedReason(configItem) != nil
+}
+
+// IsSystemApplication returns true if the application type is one of the PingOne-owned applications
+func IsSystemApplication(applicationType management.EnumApplicationType) bool {
+	return Contains(systemApplicationTypes, applicationType)
+}
+
+func (p ProtectionConfig) protectedReason(configItem ConfigItem) *string {
+
+	for _, id := range p.IDs {
+		if strings.EqualFold(configItem.Id, id) {
+			reason := fmt.Sprintf(`"%s" is in the protected ID list`, configItem.Id)
+			return &reason
+		}
+	}
+
+	for _, name := range p.Names {
+		if strings.EqualFold(configItem.IdentifierToEvaluate, name) {
+			reason := fmt.Sprintf(`"%s" is in the protected name list`, configItem.IdentifierToEvaluate)
+			return &reason
+		}
+	}
+
+	if p.Marker != "" {
+		if configItem.Description != nil && strings.Contains(*configItem.Description, p.Marker) {
+			reason := fmt.Sprintf(`"%s" has the protection marker "%s" in its description`, configItem.IdentifierToEvaluate, p.Marker)
+			return &reason
+		}
+
+		if strings.Contains(configItem.IdentifierToEvaluate, p.Marker) {
+			reason := fmt.Sprintf(`"%s" has the protection marker "%s" in its name`, configItem.IdentifierToEvaluate, p.Marker)
+			return &reason
+		}
+	}
+
+	return nil
+}
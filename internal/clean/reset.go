@@ -0,0 +1,340 @@
+package clean
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// DesiredState holds the desired settings of singleton configuration, keyed by the singleton's name (for example "mfa-settings").  Settings use the
+// field names of the PingOne API, and only the fields given are compared and reset.
+type DesiredState map[string]map[string]any
+
+// FieldDiff is a single setting whose current value differs from the desired state.  A nil Current means the setting is not set.
+type FieldDiff struct {
+	Path    string
+	Current any
+	Desired any
+}
+
+// ReadDesiredStateFile reads the desired state of singleton configuration from a YAML (or JSON) file
+func ReadDesiredStateFile(path string) (DesiredState, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read the desired state file: %w", err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(fileBytes, &raw); err != nil {
+		return nil, fmt.Errorf("Cannot parse the desired state file: %w", err)
+	}
+
+	desiredState := make(DesiredState)
+	for key, v := range raw {
+		// Round trip each section through JSON, so that values compare equally with the settings read from the API
+		settings, err := toJSONObject(v)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot parse the desired state of \"%s\": %w", key, err)
+		}
+
+		desiredState[key] = settings
+	}
+
+	return desiredState, nil
+}
+
+// DiffSettings compares the current settings object with the desired settings, returning the differences ordered by path.  Fields that are not in the
+// desired settings are not compared.
+func DiffSettings(current any, desired map[string]any) ([]FieldDiff, error) {
+	currentObject, err := toJSONObject(current)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]FieldDiff, 0)
+	diffObjects("", currentObject, desired, &diffs)
+
+	return diffs, nil
+}
+
+var (
+	// The metadata keys of settings objects that are set by PingOne, removed before the settings are sent back
+	readOnlySettingKeys = []string{
+		"_links",
+		"createdAt",
+		"environment",
+		"id",
+		"updatedAt",
+	}
+)
+
+// MergeSettings applies the desired settings over the current settings object and decodes the result into target, so that the fields not in the desired
+// settings keep their current values.  Read only metadata is not carried over, and desired settings that the target cannot hold (for example a
+// misspelled field) are rejected, as they would otherwise be dropped from every update and never reach the desired state.
+func MergeSettings(current any, desired map[string]any, target any) error {
+	currentObject, err := toJSONObject(current)
+	if err != nil {
+		return err
+	}
+
+	mergeObjects(currentObject, desired)
+	removeReadOnlySettings(currentObject)
+
+	mergedBytes, err := json.Marshal(currentObject)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(mergedBytes, target); err != nil {
+		return err
+	}
+
+	targetObject, err := toJSONObject(target)
+	if err != nil {
+		return err
+	}
+
+	unsupported := make([]string, 0)
+	unsupportedFields("", targetObject, desired, &unsupported)
+
+	if len(unsupported) > 0 {
+		return fmt.Errorf("The desired state fields \"%s\" are unknown, read only or have unsupported values", strings.Join(unsupported, `", "`))
+	}
+
+	return nil
+}
+
+// WritableSettings returns the JSON object of configuration read from the API without the read only metadata set by PingOne, so that it can be sent
+// back in an update
+func WritableSettings(v any) (map[string]any, error) {
+	object, err := toJSONObject(v)
+	if err != nil {
+		return nil, err
+	}
+
+	removeReadOnlySettings(object)
+
+	return object, nil
+}
+
+// TryResetConfig resets a singleton configuration item to the desired settings, where it differs.  The differences are reported at field level, including in a dry run.
+func TryResetConfig(ctx context.Context, configKey string, env CleanEnvironmentConfig, configItem ConfigItem, desired map[string]any, resetSdkFunction sdk.SDKInterfaceFunc) error {
+	l := logger.Get()
+
+	if resetSdkFunction == nil {
+		return fmt.Errorf("[%s] No SDK functions provided", configKey)
+	}
+
+	output := CleanOutput{
+		ConfigItem: configItem,
+		Action:     ENUMCLEANOUTPUTACTION_RESET,
+	}
+
+	if message := env.Protection.protectedReason(configItem); message != nil {
+		l.Info().Msgf(`[%s] No action taken: %s`, configKey, *message)
+
+		output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_PROTECTED
+		output.Message = message
+		handleOutput(configKey, output, env.DryRun)
+
+		return nil
+	}
+
+	diffs, err := DiffSettings(configItem.Object, desired)
+	if err != nil {
+		return fmt.Errorf("[%s] Cannot compare to the desired state: %w", configKey, err)
+	}
+
+	if len(diffs) == 0 {
+		message := fmt.Sprintf(`"%s" matches the desired state`, configItem.IdentifierToEvaluate)
+		l.Info().Msgf(`[%s] No action taken: %s`, configKey, message)
+
+		output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_OK
+		output.Message = &message
+		handleOutput(configKey, output, env.DryRun)
+
+		return nil
+	}
+
+	changes := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		l.Debug().Msgf(`[%s] "%s" field %s differs from the desired state`, configKey, configItem.IdentifierToEvaluate, diff.Path)
+		changes = append(changes, diff.String())
+	}
+
+	message := strings.Join(changes, "; ")
+	output.Message = &message
+
+	if env.Plan != nil {
+		l.Debug().Msgf(`[%s] Adding %s action for "%s" to the plan`, configKey, output.Action, configItem.IdentifierToEvaluate)
+		env.Plan.add(configKey, env, output, resetSdkFunction)
+
+		return nil
+	}
+
+	if env.Prompter != nil && !env.DryRun {
+		confirmed, err := env.Prompter.confirm(configKey, configItem, output.Action, output.Message)
+		if err != nil {
+			return err
+		}
+
+		if !confirmed {
+			message := fmt.Sprintf(`%s action for "%s" was declined`, output.Action, configItem.IdentifierToEvaluate)
+			l.Info().Msgf(`[%s] No action taken: %s`, configKey, message)
+
+			output.Result = ENUMCLEANOUTPUTRESULT_NOACTION_SKIPPED
+			output.Message = &message
+			handleOutput(configKey, output, env.DryRun)
+
+			return nil
+		}
+	}
+
+	return executeAction(ctx, configKey, env, output, resetSdkFunction)
+}
+
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Path, formatSettingValue(d.Current), formatSettingValue(d.Desired))
+}
+
+func formatSettingValue(v any) string {
+	if v == nil {
+		return "(not set)"
+	}
+
+	valueBytes, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(valueBytes)
+}
+
+func diffObjects(path string, current, desired map[string]any, diffs *[]FieldDiff) {
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldPath := key
+		if path != "" {
+			fieldPath = fmt.Sprintf("%s.%s", path, key)
+		}
+
+		desiredValue := desired[key]
+		currentValue := current[key]
+
+		desiredObject, desiredIsObject := desiredValue.(map[string]any)
+		currentObject, currentIsObject := currentValue.(map[string]any)
+
+		if desiredIsObject && (currentIsObject || currentValue == nil) {
+			diffObjects(fieldPath, currentObject, desiredObject, diffs)
+			continue
+		}
+
+		if !settingValuesEqual(currentValue, desiredValue) {
+			*diffs = append(*diffs, FieldDiff{
+				Path:    fieldPath,
+				Current: currentValue,
+				Desired: desiredValue,
+			})
+		}
+	}
+}
+
+// unsupportedFields appends the paths of the desired settings that are missing from, or differ in, the decoded settings object
+func unsupportedFields(path string, decoded, desired map[string]any, unsupported *[]string) {
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldPath := key
+		if path != "" {
+			fieldPath = fmt.Sprintf("%s.%s", path, key)
+		}
+
+		// Unsetting a field leaves it out of the decoded object
+		decodedValue, ok := decoded[key]
+		if !ok && desired[key] != nil {
+			*unsupported = append(*unsupported, fieldPath)
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		desiredObject, desiredIsObject := desired[key].(map[string]any)
+		decodedObject, decodedIsObject := decodedValue.(map[string]any)
+
+		if desiredIsObject && decodedIsObject {
+			unsupportedFields(fieldPath, decodedObject, desiredObject, unsupported)
+			continue
+		}
+
+		if !settingValuesEqual(decodedValue, desired[key]) {
+			*unsupported = append(*unsupported, fieldPath)
+		}
+	}
+}
+
+func removeReadOnlySettings(object map[string]any) {
+	for _, key := range readOnlySettingKeys {
+		delete(object, key)
+	}
+}
+
+func mergeObjects(current, desired map[string]any) {
+	for key, desiredValue := range desired {
+		desiredObject, desiredIsObject := desiredValue.(map[string]any)
+		currentObject, currentIsObject := current[key].(map[string]any)
+
+		if desiredIsObject && currentIsObject {
+			mergeObjects(currentObject, desiredObject)
+			continue
+		}
+
+		current[key] = desiredValue
+	}
+}
+
+func settingValuesEqual(a, b any) bool {
+	aBytes, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+
+	bBytes, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(aBytes, bBytes)
+}
+
+func toJSONObject(v any) (map[string]any, error) {
+	objectBytes, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	object := make(map[string]any)
+	if err := json.Unmarshal(objectBytes, &object); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
@@ -0,0 +1,112 @@
+package clean
+
+import (
+	"strings"
+	"testing"
+)
+
+type testSettingsLockout struct {
+	FailureCount    *int `json:"failureCount,omitempty"`
+	DurationSeconds *int `json:"durationSeconds,omitempty"`
+}
+
+type testSettings struct {
+	Id          *string              `json:"id,omitempty"`
+	Environment *map[string]string   `json:"environment,omitempty"`
+	Name        *string              `json:"name,omitempty"`
+	Lockout     *testSettingsLockout `json:"lockout,omitempty"`
+}
+
+func TestMergeSettings(t *testing.T) {
+
+	id := "1"
+	name := "current"
+	failureCount := 5
+	durationSeconds := 60
+	current := testSettings{
+		Id:          &id,
+		Environment: &map[string]string{"id": "env"},
+		Name:        &name,
+		Lockout: &testSettingsLockout{
+			FailureCount:    &failureCount,
+			DurationSeconds: &durationSeconds,
+		},
+	}
+
+	tests := []struct {
+		name          string
+		desired       map[string]any
+		expectedError string
+		check         func(*testing.T, testSettings)
+	}{
+		{
+			name:    "desired fields are merged and other fields are kept",
+			desired: map[string]any{"lockout": map[string]any{"failureCount": float64(3)}},
+			check: func(t *testing.T, merged testSettings) {
+				if *merged.Lockout.FailureCount != 3 {
+					t.Errorf("expected failure count 3, got %d", *merged.Lockout.FailureCount)
+				}
+				if *merged.Lockout.DurationSeconds != 60 {
+					t.Errorf("expected duration 60 to be kept, got %d", *merged.Lockout.DurationSeconds)
+				}
+				if *merged.Name != "current" {
+					t.Errorf("expected name to be kept, got %s", *merged.Name)
+				}
+			},
+		},
+		{
+			name:    "read only metadata is not carried over",
+			desired: map[string]any{"name": "desired"},
+			check: func(t *testing.T, merged testSettings) {
+				if merged.Id != nil || merged.Environment != nil {
+					t.Errorf("expected read only fields to be removed, got %v and %v", merged.Id, merged.Environment)
+				}
+			},
+		},
+		{
+			name:          "unknown nested fields are rejected",
+			desired:       map[string]any{"lockout": map[string]any{"failurecount": float64(3)}},
+			expectedError: `"lockout.failurecount"`,
+		},
+		{
+			name:          "unknown top level fields are rejected",
+			desired:       map[string]any{"nmae": "desired"},
+			expectedError: `"nmae"`,
+		},
+		{
+			name:          "read only fields are rejected",
+			desired:       map[string]any{"id": "2"},
+			expectedError: `"id"`,
+		},
+		{
+			name:    "fields can be unset",
+			desired: map[string]any{"name": nil},
+			check: func(t *testing.T, merged testSettings) {
+				if merged.Name != nil {
+					t.Errorf("expected name to be unset, got %s", *merged.Name)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var merged testSettings
+			err := MergeSettings(current, tt.desired, &merged)
+
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("expected an error containing %s, got %v", tt.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("MergeSettings returned an error: %s", err)
+			}
+
+			tt.check(t, merged)
+		})
+	}
+}
@@ -0,0 +1,1122 @@
+package clean
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Rule is a named selection expression evaluated against the JSON representation of a configuration item.  The expression language is a small,
+// CEL-like subset:
+//
+//   - literals: strings ("..." or '...'), numbers, true, false, null
+//   - item fields by name, with nested access using "." or ["key"].  Fields of the item that are not set evaluate to null, and fields that the item
+//     does not have are an error, so that a misspelled field cannot select items
+//   - operators: ! && || == != < <= > >= + - and parentheses.  The logical operators only accept booleans, so a null must be compared explicitly
+//   - functions: now(), duration("30d"), timestamp("2024-01-01T00:00:00Z"), size(x), has(x) (whether the field x is set)
+//   - string methods: startsWith, endsWith, contains, matches (regular expression), lowerAscii, upperAscii
+//
+// Timestamps returned by the API as strings are converted when compared with, or added to, a timestamp or duration.  Durations accept the units
+// understood by time.ParseDuration plus "d" (days) and "w" (weeks).
+//
+// Example: `createdAt < now() - duration("30d") && name.startsWith("tf-acc-")`
+type Rule struct {
+	Name       string
+	Expression string
+	program    ruleNode
+}
+
+// CompileRules compiles a map of rule names to expressions.  Rules are returned sorted by name, which is the order they are evaluated in.
+func CompileRules(rules map[string]string) ([]Rule, error) {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	compiledRules := make([]Rule, 0, len(rules))
+	for _, name := range names {
+		rule, err := CompileRule(name, rules[name])
+		if err != nil {
+			return nil, err
+		}
+		compiledRules = append(compiledRules, *rule)
+	}
+
+	return compiledRules, nil
+}
+
+func CompileRule(name, expression string) (*Rule, error) {
+	tokens, err := tokenizeRule(expression)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot compile rule \"%s\": %w", name, err)
+	}
+
+	p := &ruleParser{
+		tokens: tokens,
+	}
+
+	program, err := p.parseExpression()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot compile rule \"%s\": %w", name, err)
+	}
+
+	if p.peek().kind != ruleTokenEOF {
+		return nil, fmt.Errorf("Cannot compile rule \"%s\": unexpected \"%s\" at position %d", name, p.peek().text, p.peek().pos)
+	}
+
+	return &Rule{
+		Name:       name,
+		Expression: expression,
+		program:    program,
+	}, nil
+}
+
+// Evaluate runs the rule against the JSON representation of the object.  The rule must evaluate to a boolean.
+func (r *Rule) Evaluate(object any) (bool, error) {
+	if r.program == nil {
+		return false, fmt.Errorf("Rule \"%s\" has not been compiled", r.Name)
+	}
+
+	objectBytes, err := json.Marshal(object)
+	if err != nil {
+		return false, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(objectBytes, &fields); err != nil {
+		return false, fmt.Errorf("Rule \"%s\" can only be evaluated against JSON objects: %w", r.Name, err)
+	}
+
+	addKnownFields(reflect.ValueOf(object), fields)
+
+	ctx := &ruleContext{
+		fields: fields,
+		now:    time.Now(),
+	}
+
+	result, err := r.program.eval(ctx)
+	if err != nil {
+		return false, fmt.Errorf("Cannot evaluate rule \"%s\": %w", r.Name, err)
+	}
+
+	return toBool(result)
+}
+
+// Tokenizer
+
+type ruleTokenKind int
+
+const (
+	ruleTokenEOF ruleTokenKind = iota
+	ruleTokenIdent
+	ruleTokenString
+	ruleTokenNumber
+	ruleTokenOperator
+)
+
+type ruleToken struct {
+	kind ruleTokenKind
+	text string
+	pos  int
+}
+
+func tokenizeRule(expression string) ([]ruleToken, error) {
+	tokens := make([]ruleToken, 0)
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, ruleToken{kind: ruleTokenIdent, text: string(runes[start:i]), pos: start})
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, ruleToken{kind: ruleTokenNumber, text: string(runes[start:i]), pos: start})
+
+		case r == '"' || r == '\'':
+			start := i
+			quote := r
+			i++
+			var sb strings.Builder
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("unterminated string at position %d", start)
+				}
+				if runes[i] == '\\' && i+1 < len(runes) {
+					sb.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == quote {
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, ruleToken{kind: ruleTokenString, text: sb.String(), pos: start})
+
+		default:
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "&&", "||", "==", "!=", "<=", ">=":
+					tokens = append(tokens, ruleToken{kind: ruleTokenOperator, text: two, pos: i})
+					i += 2
+					continue
+				}
+			}
+
+			if strings.ContainsRune("!<>+-().,[]", r) {
+				tokens = append(tokens, ruleToken{kind: ruleTokenOperator, text: string(r), pos: i})
+				i++
+				continue
+			}
+
+			return nil, fmt.Errorf("unexpected character '%c' at position %d", r, i)
+		}
+	}
+
+	tokens = append(tokens, ruleToken{kind: ruleTokenEOF, pos: len(runes)})
+
+	return tokens, nil
+}
+
+// Parser
+
+type ruleParser struct {
+	tokens []ruleToken
+	pos    int
+}
+
+func (p *ruleParser) peek() ruleToken {
+	return p.tokens[p.pos]
+}
+
+func (p *ruleParser) next() ruleToken {
+	t := p.tokens[p.pos]
+	if t.kind != ruleTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *ruleParser) acceptOperator(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != ruleTokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *ruleParser) expectOperator(op string) error {
+	if _, ok := p.acceptOperator(op); !ok {
+		t := p.peek()
+		if t.kind == ruleTokenEOF {
+			return fmt.Errorf("expected \"%s\" but reached the end of the expression", op)
+		}
+		return fmt.Errorf("expected \"%s\" but found \"%s\" at position %d", op, t.text, t.pos)
+	}
+	return nil
+}
+
+func (p *ruleParser) parseExpression() (ruleNode, error) {
+	return p.parseOr()
+}
+
+func (p *ruleParser) parseOr() (ruleNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.acceptOperator("||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &ruleLogicalNode{op: "||", left: left, right: right}
+	}
+}
+
+func (p *ruleParser) parseAnd() (ruleNode, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.acceptOperator("&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = &ruleLogicalNode{op: "&&", left: left, right: right}
+	}
+}
+
+func (p *ruleParser) parseComparison() (ruleNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	if op, ok := p.acceptOperator("==", "!=", "<", "<=", ">", ">="); ok {
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &ruleComparisonNode{op: op, left: left, right: right}, nil
+	}
+
+	return left, nil
+}
+
+func (p *ruleParser) parseAdditive() (ruleNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.acceptOperator("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &ruleArithmeticNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *ruleParser) parseUnary() (ruleNode, error) {
+	if op, ok := p.acceptOperator("!", "-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &ruleUnaryNode{op: op, operand: operand}, nil
+	}
+
+	return p.parsePostfix()
+}
+
+func (p *ruleParser) parsePostfix() (ruleNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.acceptOperator("."); ok {
+			t := p.next()
+			if t.kind != ruleTokenIdent {
+				return nil, fmt.Errorf("expected a field or method name at position %d", t.pos)
+			}
+
+			if _, ok := p.acceptOperator("("); ok {
+				args, err := p.parseArguments()
+				if err != nil {
+					return nil, err
+				}
+				node = &ruleCallNode{name: t.text, receiver: node, args: args}
+			} else {
+				node = &ruleFieldNode{name: t.text, object: node}
+			}
+			continue
+		}
+
+		if _, ok := p.acceptOperator("["); ok {
+			key, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOperator("]"); err != nil {
+				return nil, err
+			}
+			node = &ruleIndexNode{object: node, key: key}
+			continue
+		}
+
+		return node, nil
+	}
+}
+
+func (p *ruleParser) parseArguments() ([]ruleNode, error) {
+	args := make([]ruleNode, 0)
+
+	if _, ok := p.acceptOperator(")"); ok {
+		return args, nil
+	}
+
+	for {
+		arg, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		if _, ok := p.acceptOperator(","); ok {
+			continue
+		}
+		if err := p.expectOperator(")"); err != nil {
+			return nil, err
+		}
+		return args, nil
+	}
+}
+
+func (p *ruleParser) parsePrimary() (ruleNode, error) {
+	t := p.next()
+
+	switch t.kind {
+	case ruleTokenNumber:
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number \"%s\" at position %d", t.text, t.pos)
+		}
+		return &ruleLiteralNode{value: v}, nil
+
+	case ruleTokenString:
+		return &ruleLiteralNode{value: t.text}, nil
+
+	case ruleTokenIdent:
+		switch t.text {
+		case "true":
+			return &ruleLiteralNode{value: true}, nil
+		case "false":
+			return &ruleLiteralNode{value: false}, nil
+		case "null":
+			return &ruleLiteralNode{value: nil}, nil
+		}
+
+		if _, ok := p.acceptOperator("("); ok {
+			args, err := p.parseArguments()
+			if err != nil {
+				return nil, err
+			}
+			return &ruleCallNode{name: t.text, args: args}, nil
+		}
+
+		return &ruleFieldNode{name: t.text}, nil
+
+	case ruleTokenOperator:
+		if t.text == "(" {
+			node, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOperator(")"); err != nil {
+				return nil, err
+			}
+			return node, nil
+		}
+	}
+
+	if t.kind == ruleTokenEOF {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	return nil, fmt.Errorf("unexpected \"%s\" at position %d", t.text, t.pos)
+}
+
+// Evaluation
+
+type ruleContext struct {
+	fields map[string]any
+	now    time.Time
+}
+
+type ruleNode interface {
+	eval(ctx *ruleContext) (any, error)
+}
+
+type ruleLiteralNode struct {
+	value any
+}
+
+func (n *ruleLiteralNode) eval(_ *ruleContext) (any, error) {
+	return n.value, nil
+}
+
+type ruleFieldNode struct {
+	name   string
+	object ruleNode
+}
+
+func (n *ruleFieldNode) eval(ctx *ruleContext) (any, error) {
+	if n.object == nil {
+		return lookupField(ctx.fields, n.name)
+	}
+
+	object, err := n.object.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return lookupField(object, n.name)
+}
+
+type ruleIndexNode struct {
+	object ruleNode
+	key    ruleNode
+}
+
+func (n *ruleIndexNode) eval(ctx *ruleContext) (any, error) {
+	object, err := n.object.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := n.key.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := key.(type) {
+	case string:
+		return lookupField(object, k)
+	case float64:
+		list, ok := object.([]any)
+		if object == nil {
+			return nil, nil
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot index %s with a number", typeName(object))
+		}
+		if int(k) < 0 || int(k) >= len(list) {
+			return nil, nil
+		}
+		return list[int(k)], nil
+	default:
+		return nil, fmt.Errorf("invalid index type %s", typeName(key))
+	}
+}
+
+func lookupField(object any, name string) (any, error) {
+	switch o := object.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		v, ok := o[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field \"%s\"", name)
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("cannot access field \"%s\" of %s", name, typeName(object))
+	}
+}
+
+// hasField implements has(x), which tests whether the field x is set without failing when it is not a field of the item
+func hasField(ctx *ruleContext, args []ruleNode) (any, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("has() takes one argument")
+	}
+
+	var objectNode ruleNode
+	var name string
+	switch arg := args[0].(type) {
+	case *ruleFieldNode:
+		objectNode, name = arg.object, arg.name
+	case *ruleIndexNode:
+		key, err := arg.key.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		k, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("has() requires a field, got an index of %s", typeName(key))
+		}
+		objectNode, name = arg.object, k
+	default:
+		return nil, fmt.Errorf("has() requires a field")
+	}
+
+	var object any = ctx.fields
+	if objectNode != nil {
+		var err error
+		object, err = objectNode.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields, ok := object.(map[string]any)
+	if !ok {
+		return false, nil
+	}
+
+	return fields[name] != nil, nil
+}
+
+// addKnownFields adds the fields of the object's type that are left out of its JSON representation as null, so that fields that are not set can
+// be told apart from fields the item does not have.  OneOf wrappers (structs without JSON tags) are represented by the variant that is set.
+func addKnownFields(v reflect.Value, decoded any) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		fields, ok := decoded.(map[string]any)
+		if !ok {
+			return
+		}
+
+		t := v.Type()
+		tagged := false
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "" {
+				continue
+			}
+
+			tagged = true
+			if name == "-" {
+				continue
+			}
+
+			if value, ok := fields[name]; ok {
+				addKnownFields(v.Field(i), value)
+			} else {
+				fields[name] = nil
+			}
+		}
+
+		if tagged {
+			return
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			if variant := v.Field(i); t.Field(i).IsExported() && variant.Kind() == reflect.Pointer && !variant.IsNil() {
+				addKnownFields(variant, decoded)
+				return
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		list, ok := decoded.([]any)
+		if !ok {
+			return
+		}
+
+		for i := 0; i < v.Len() && i < len(list); i++ {
+			addKnownFields(v.Index(i), list[i])
+		}
+	}
+}
+
+type ruleUnaryNode struct {
+	op      string
+	operand ruleNode
+}
+
+func (n *ruleUnaryNode) eval(ctx *ruleContext) (any, error) {
+	v, err := n.operand.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "!":
+		b, err := toBool(v)
+		if err != nil {
+			return nil, err
+		}
+		return !b, nil
+	default:
+		switch t := v.(type) {
+		case float64:
+			return -t, nil
+		case time.Duration:
+			return -t, nil
+		default:
+			return nil, fmt.Errorf("cannot negate %s", typeName(v))
+		}
+	}
+}
+
+type ruleLogicalNode struct {
+	op    string
+	left  ruleNode
+	right ruleNode
+}
+
+func (n *ruleLogicalNode) eval(ctx *ruleContext) (any, error) {
+	l, err := n.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lb, err := toBool(l)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "&&" && !lb {
+		return false, nil
+	}
+	if n.op == "||" && lb {
+		return true, nil
+	}
+
+	r, err := n.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBool(r)
+}
+
+type ruleComparisonNode struct {
+	op    string
+	left  ruleNode
+	right ruleNode
+}
+
+func (n *ruleComparisonNode) eval(ctx *ruleContext) (any, error) {
+	l, err := n.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := n.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l, r, err = coerceTimestamps(l, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "==" || n.op == "!=" {
+		eq := valuesEqual(l, r)
+		if n.op == "!=" {
+			return !eq, nil
+		}
+		return eq, nil
+	}
+
+	// Ordering comparisons against null (for example a missing field) are never true
+	if l == nil || r == nil {
+		return false, nil
+	}
+
+	var cmp int
+	switch lv := l.(type) {
+	case float64:
+		rv, ok := r.(float64)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %s with %s", typeName(l), typeName(r))
+		}
+		cmp = compareOrdered(lv, rv)
+	case string:
+		rv, ok := r.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %s with %s", typeName(l), typeName(r))
+		}
+		cmp = strings.Compare(lv, rv)
+	case time.Time:
+		rv, ok := r.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %s with %s", typeName(l), typeName(r))
+		}
+		cmp = lv.Compare(rv)
+	case time.Duration:
+		rv, ok := r.(time.Duration)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %s with %s", typeName(l), typeName(r))
+		}
+		cmp = compareOrdered(lv, rv)
+	default:
+		return nil, fmt.Errorf("cannot order %s values", typeName(l))
+	}
+
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+func compareOrdered[T float64 | time.Duration](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func valuesEqual(l, r any) bool {
+	switch lv := l.(type) {
+	case time.Time:
+		rv, ok := r.(time.Time)
+		return ok && lv.Equal(rv)
+	case map[string]any, []any:
+		lb, _ := json.Marshal(l)
+		rb, _ := json.Marshal(r)
+		return string(lb) == string(rb)
+	default:
+		return l == r
+	}
+}
+
+type ruleArithmeticNode struct {
+	op    string
+	left  ruleNode
+	right ruleNode
+}
+
+func (n *ruleArithmeticNode) eval(ctx *ruleContext) (any, error) {
+	l, err := n.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := n.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l, r, err = coerceTimestamps(l, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if l == nil || r == nil {
+		return nil, nil
+	}
+
+	sign := 1
+	if n.op == "-" {
+		sign = -1
+	}
+
+	switch lv := l.(type) {
+	case float64:
+		if rv, ok := r.(float64); ok {
+			return lv + float64(sign)*rv, nil
+		}
+	case string:
+		if rv, ok := r.(string); ok && n.op == "+" {
+			return lv + rv, nil
+		}
+	case time.Time:
+		switch rv := r.(type) {
+		case time.Duration:
+			return lv.Add(time.Duration(sign) * rv), nil
+		case time.Time:
+			if n.op == "-" {
+				return lv.Sub(rv), nil
+			}
+		}
+	case time.Duration:
+		switch rv := r.(type) {
+		case time.Duration:
+			return lv + time.Duration(sign)*rv, nil
+		case time.Time:
+			if n.op == "+" {
+				return rv.Add(lv), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("cannot apply \"%s\" to %s and %s", n.op, typeName(l), typeName(r))
+}
+
+// coerceTimestamps converts a string operand to a timestamp where the other operand is a timestamp or duration, as the API returns timestamps as strings
+func coerceTimestamps(l, r any) (any, any, error) {
+	isTimeLike := func(v any) bool {
+		switch v.(type) {
+		case time.Time, time.Duration:
+			return true
+		}
+		return false
+	}
+
+	var err error
+	if s, ok := l.(string); ok && isTimeLike(r) {
+		if l, err = parseRuleTimestamp(s); err != nil {
+			return nil, nil, err
+		}
+	}
+	if s, ok := r.(string); ok && isTimeLike(l) {
+		if r, err = parseRuleTimestamp(s); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return l, r, nil
+}
+
+type ruleCallNode struct {
+	name     string
+	receiver ruleNode
+	args     []ruleNode
+}
+
+func (n *ruleCallNode) eval(ctx *ruleContext) (any, error) {
+	if n.receiver == nil && n.name == "has" {
+		return hasField(ctx, n.args)
+	}
+
+	args := make([]any, 0, len(n.args))
+	for _, argNode := range n.args {
+		arg, err := argNode.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+
+	if n.receiver != nil {
+		receiver, err := n.receiver.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return callMethod(n.name, receiver, args)
+	}
+
+	return callFunction(ctx, n.name, args)
+}
+
+func callFunction(ctx *ruleContext, name string, args []any) (any, error) {
+	switch name {
+	case "now":
+		if len(args) != 0 {
+			return nil, fmt.Errorf("now() takes no arguments")
+		}
+		return ctx.now, nil
+
+	case "duration":
+		s, err := singleStringArgument(name, args)
+		if err != nil {
+			return nil, err
+		}
+		return parseRuleDuration(s)
+
+	case "timestamp":
+		s, err := singleStringArgument(name, args)
+		if err != nil {
+			return nil, err
+		}
+		return parseRuleTimestamp(s)
+
+	case "size":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("size() takes one argument")
+		}
+		return sizeOf(args[0])
+	}
+
+	return nil, fmt.Errorf("unknown function \"%s\"", name)
+}
+
+func callMethod(name string, receiver any, args []any) (any, error) {
+	if name == "size" {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("size() takes no arguments")
+		}
+		return sizeOf(receiver)
+	}
+
+	// String methods on missing fields evaluate to null, so that a rule simply doesn't match
+	if receiver == nil {
+		return nil, nil
+	}
+
+	s, ok := receiver.(string)
+	if !ok {
+		return nil, fmt.Errorf("method \"%s\" is not supported on %s", name, typeName(receiver))
+	}
+
+	switch name {
+	case "lowerAscii":
+		return strings.ToLower(s), nil
+	case "upperAscii":
+		return strings.ToUpper(s), nil
+	}
+
+	arg, err := singleStringArgument(name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case "startsWith":
+		return strings.HasPrefix(s, arg), nil
+	case "endsWith":
+		return strings.HasSuffix(s, arg), nil
+	case "contains":
+		return strings.Contains(s, arg), nil
+	case "matches":
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString(s), nil
+	}
+
+	return nil, fmt.Errorf("unknown method \"%s\"", name)
+}
+
+func singleStringArgument(name string, args []any) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("%s() takes one argument", name)
+	}
+
+	s, ok := args[0].(string)
+	if !ok {
+		return "", fmt.Errorf("%s() requires a string argument, got %s", name, typeName(args[0]))
+	}
+
+	return s, nil
+}
+
+func sizeOf(v any) (any, error) {
+	switch t := v.(type) {
+	case nil:
+		return float64(0), nil
+	case string:
+		return float64(len([]rune(t))), nil
+	case []any:
+		return float64(len(t)), nil
+	case map[string]any:
+		return float64(len(t)), nil
+	default:
+		return nil, fmt.Errorf("size() is not supported on %s", typeName(v))
+	}
+}
+
+func parseRuleTimestamp(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp \"%s\"", s)
+	}
+	return t, nil
+}
+
+func parseRuleDuration(s string) (time.Duration, error) {
+	var total time.Duration
+
+	remaining := strings.TrimSpace(s)
+	if remaining == "" {
+		return 0, fmt.Errorf("invalid duration \"%s\"", s)
+	}
+
+	for remaining != "" {
+		i := 0
+		for i < len(remaining) && (remaining[i] == '.' || (remaining[i] >= '0' && remaining[i] <= '9')) {
+			i++
+		}
+		j := i
+		for j < len(remaining) && unicode.IsLetter(rune(remaining[j])) {
+			j++
+		}
+
+		if i == 0 || j == i {
+			return 0, fmt.Errorf("invalid duration \"%s\"", s)
+		}
+
+		number, unit := remaining[:i], remaining[i:j]
+		remaining = remaining[j:]
+
+		switch unit {
+		case "d", "w":
+			value, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration \"%s\"", s)
+			}
+			unitDuration := 24 * time.Hour
+			if unit == "w" {
+				unitDuration *= 7
+			}
+			total += time.Duration(value * float64(unitDuration))
+		default:
+			d, err := time.ParseDuration(number + unit)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration \"%s\"", s)
+			}
+			total += d
+		}
+	}
+
+	return total, nil
+}
+
+func toBool(v any) (bool, error) {
+	switch t := v.(type) {
+	case bool:
+		return t, nil
+	default:
+		return false, fmt.Errorf("expected a boolean, got %s", typeName(v))
+	}
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case time.Time:
+		return "timestamp"
+	case time.Duration:
+		return "duration"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,341 @@
+package clean
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRuleEvaluate(t *testing.T) {
+
+	object := map[string]any{
+		"name":        "tf-acc-app",
+		"enabled":     true,
+		"count":       3,
+		"createdAt":   "2024-01-01T00:00:00Z",
+		"description": nil,
+		"tags":        []string{"demo", "test"},
+		"settings": map[string]any{
+			"type":        "OIDC",
+			"grant-types": []string{"CLIENT_CREDENTIALS"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   bool
+	}{
+		// Literals and fields
+		{"boolean field", `enabled`, true},
+		{"true literal", `true`, true},
+		{"false literal", `false`, false},
+
+		// Comparison operators
+		{"string equality", `name == "tf-acc-app"`, true},
+		{"single quoted string", `name == 'tf-acc-app'`, true},
+		{"string inequality", `name != "other"`, true},
+		{"number equality", `count == 3`, true},
+		{"number less than", `count < 4`, true},
+		{"number less than or equal", `count <= 3`, true},
+		{"number greater than", `count > 3`, false},
+		{"number greater than or equal", `count >= 3`, true},
+		{"decimal number", `count > 2.5`, true},
+		{"string ordering", `name > "a"`, true},
+
+		// Logical operators
+		{"and", `enabled && count == 3`, true},
+		{"and short circuits", `false && unknownFunction()`, false},
+		{"or", `count == 1 || count == 3`, true},
+		{"or short circuits", `true || unknownFunction()`, true},
+		{"not", `!enabled`, false},
+		{"not of comparison", `!(count == 3)`, false},
+
+		// Precedence
+		{"and binds tighter than or", `true || false && false`, true},
+		{"parentheses override precedence", `(true || false) && false`, false},
+		{"comparison binds tighter than and", `count == 3 && name == "tf-acc-app"`, true},
+		{"arithmetic binds tighter than comparison", `count + 1 == 4`, true},
+		{"arithmetic is left associative", `10 - 4 - 3 == 3`, true},
+		{"unary minus", `-count == -3`, true},
+
+		// Arithmetic and strings
+		{"string concatenation", `name + "-x" == "tf-acc-app-x"`, true},
+
+		// Nested access
+		{"dot access", `settings.type == "OIDC"`, true},
+		{"index access by key", `settings["grant-types"][0] == "CLIENT_CREDENTIALS"`, true},
+		{"list index", `tags[1] == "test"`, true},
+		{"list index out of range is null", `tags[5] == null`, true},
+
+		// Null handling
+		{"null field equals null", `description == null`, true},
+		{"null field is not equal to a value", `description != "x"`, true},
+		{"ordering against null is false", `description < 3`, false},
+		{"ordering against null is false when reversed", `3 > description`, false},
+		{"arithmetic with null is null", `description + 1 == null`, true},
+		{"string method on null is null", `description.startsWith("x") == null`, true},
+		{"size of null is zero", `size(description) == 0`, true},
+		{"has a set field", `has(name)`, true},
+		{"has a null field", `has(description)`, false},
+		{"has an unknown field", `has(missing)`, false},
+		{"has a nested field", `has(settings.type)`, true},
+		{"has a nested field by key", `has(settings["grant-types"])`, true},
+		{"has guards a field", `has(missing) && missing.startsWith("x")`, false},
+
+		// Functions and methods
+		{"startsWith", `name.startsWith("tf-acc-")`, true},
+		{"endsWith", `name.endsWith("-app")`, true},
+		{"contains", `name.contains("acc")`, true},
+		{"matches", `name.matches("^tf-[a-z]+-app$")`, true},
+		{"lowerAscii", `settings.type.lowerAscii() == "oidc"`, true},
+		{"upperAscii", `name.upperAscii() == "TF-ACC-APP"`, true},
+		{"size function", `size(tags) == 2`, true},
+		{"size method", `name.size() == 10`, true},
+		{"size of object", `size(settings) == 2`, true},
+
+		// Timestamp coercion
+		{"string field compared to timestamp", `createdAt < timestamp("2025-01-01T00:00:00Z")`, true},
+		{"timestamp compared to string field", `timestamp("2023-01-01T00:00:00Z") < createdAt`, true},
+		{"string field equals timestamp", `createdAt == timestamp("2024-01-01T00:00:00Z")`, true},
+		{"string field compared to now minus duration", `createdAt < now() - duration("30d")`, true},
+		{"string field plus duration", `createdAt + duration("1w") == timestamp("2024-01-08T00:00:00Z")`, true},
+		{"timestamp difference is a duration", `timestamp("2024-01-02T00:00:00Z") - createdAt == duration("24h")`, true},
+		{"compound duration", `duration("1d12h") == duration("36h")`, true},
+		{"duration ordering", `duration("1w") > duration("6d")`, true},
+		{"duration plus timestamp", `duration("1d") + createdAt == timestamp("2024-01-02T00:00:00Z")`, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := CompileRule(tt.name, tt.expression)
+			if err != nil {
+				t.Fatalf("CompileRule returned an error: %s", err)
+			}
+
+			result, err := rule.Evaluate(object)
+			if err != nil {
+				t.Fatalf("Evaluate returned an error: %s", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("expected %t for `%s`, got %t", tt.expected, tt.expression, result)
+			}
+		})
+	}
+}
+
+func TestRuleEvaluateErrors(t *testing.T) {
+
+	object := map[string]any{
+		"name":        "tf-acc-app",
+		"count":       3,
+		"createdAt":   "not a timestamp",
+		"description": nil,
+		"settings": map[string]any{
+			"type": "OIDC",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		expression    string
+		expectedError string
+	}{
+		{"non boolean result", `name`, "expected a boolean"},
+		{"ordering mixed types", `name < 3`, "cannot compare"},
+		{"invalid timestamp coercion", `createdAt < now()`, "invalid timestamp"},
+		{"unknown function", `unknownFunction()`, "unknown function"},
+		{"unknown method", `name.unknownMethod("x")`, "unknown method"},
+		{"method on a number", `count.startsWith("x")`, "not supported"},
+		{"invalid regular expression", `name.matches("(")`, "error parsing regexp"},
+		{"invalid duration", `now() > now() - duration("5x")`, "invalid duration"},
+		{"wrong argument type", `name.startsWith(3)`, "requires a string argument"},
+		{"negating a string", `-name == 1`, "cannot negate"},
+		{"adding mismatched types", `name + 1 == 1`, "cannot apply"},
+		{"field of a string", `name.first == 1`, "cannot access field"},
+		{"unknown field", `!missing`, "unknown field \"missing\""},
+		{"unknown field compared to null", `missing == null`, "unknown field"},
+		{"unknown nested field", `settings.missing == null`, "unknown field"},
+		{"null literal result", `null`, "expected a boolean, got null"},
+		{"negating null", `!description`, "expected a boolean, got null"},
+		{"null in a logical operator", `description || true`, "expected a boolean, got null"},
+		{"has of a literal", `has("name")`, "has() requires a field"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := CompileRule(tt.name, tt.expression)
+			if err != nil {
+				t.Fatalf("CompileRule returned an error: %s", err)
+			}
+
+			_, err = rule.Evaluate(object)
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("expected an error containing %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+type testRuleItem struct {
+	Name        string             `json:"name"`
+	Description *string            `json:"description,omitempty"`
+	Default     *bool              `json:"default,omitempty"`
+	Settings    *testRuleSettings  `json:"settings,omitempty"`
+	Tags        []testRuleSettings `json:"tags,omitempty"`
+}
+
+type testRuleSettings struct {
+	Type *string `json:"type,omitempty"`
+}
+
+// testRuleVariant is shaped like the SDK's oneOf wrappers, which are represented by the variant that is set
+type testRuleVariant struct {
+	Item     *testRuleItem
+	Settings *testRuleSettings
+}
+
+func (v testRuleVariant) MarshalJSON() ([]byte, error) {
+	if v.Item != nil {
+		return json.Marshal(v.Item)
+	}
+	return json.Marshal(v.Settings)
+}
+
+func TestRuleEvaluateKnownFields(t *testing.T) {
+
+	item := testRuleItem{
+		Name: "tf-acc-app",
+		Tags: []testRuleSettings{{}},
+	}
+
+	tests := []struct {
+		name          string
+		object        any
+		expression    string
+		expected      bool
+		expectedError string
+	}{
+		{name: "unset field is null", object: item, expression: `description == null`, expected: true},
+		{name: "unset field is not set", object: item, expression: `has(default)`, expected: false},
+		{name: "unset field compared to a value", object: item, expression: `default != true`, expected: true},
+		{name: "unset nested object is null", object: item, expression: `settings == null`, expected: true},
+		{name: "unset field of a list element is null", object: item, expression: `tags[0].type == null`, expected: true},
+		{name: "pointer to an item", object: &item, expression: `description == null`, expected: true},
+		{name: "oneOf variant fields are known", object: testRuleVariant{Item: &item}, expression: `description == null && name == "tf-acc-app"`, expected: true},
+		{name: "misspelled field", object: item, expression: `!defualt`, expectedError: "unknown field \"defualt\""},
+		{name: "misspelled field of a list element", object: item, expression: `tags[0].typo == null`, expectedError: "unknown field \"typo\""},
+		{name: "fields of another oneOf variant", object: testRuleVariant{Item: &item}, expression: `type == null`, expectedError: "unknown field \"type\""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := CompileRule(tt.name, tt.expression)
+			if err != nil {
+				t.Fatalf("CompileRule returned an error: %s", err)
+			}
+
+			result, err := rule.Evaluate(tt.object)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("expected an error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Evaluate returned an error: %s", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("expected %t for `%s`, got %t", tt.expected, tt.expression, result)
+			}
+		})
+	}
+}
+
+func TestCompileRuleErrors(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		expression    string
+		expectedError string
+	}{
+		{"empty expression", ``, "Cannot compile rule"},
+		{"unterminated string", `name == "abc`, "unterminated string"},
+		{"unexpected character", `name == #`, "unexpected character"},
+		{"missing closing parenthesis", `(name == "a"`, "Cannot compile rule"},
+		{"trailing tokens", `name == "a" "b"`, "unexpected"},
+		{"dangling operator", `name ==`, "Cannot compile rule"},
+		{"single ampersand", `enabled & true`, "unexpected character"},
+		{"missing closing bracket", `tags[0`, "Cannot compile rule"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileRule(tt.name, tt.expression)
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("expected an error containing %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCompileRulesOrder(t *testing.T) {
+
+	rules, err := CompileRules(map[string]string{
+		"b-rule": `true`,
+		"a-rule": `true`,
+		"c-rule": `false`,
+	})
+	if err != nil {
+		t.Fatalf("CompileRules returned an error: %s", err)
+	}
+
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		names = append(names, rule.Name)
+	}
+
+	if strings.Join(names, ",") != "a-rule,b-rule,c-rule" {
+		t.Errorf("expected rules sorted by name, got %s", strings.Join(names, ","))
+	}
+}
+
+func TestParseRuleDuration(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		expected time.Duration
+		valid    bool
+	}{
+		{"30d", 30 * 24 * time.Hour, true},
+		{"2w", 14 * 24 * time.Hour, true},
+		{"1.5h", 90 * time.Minute, true},
+		{"1d6h30m", 30*time.Hour + 30*time.Minute, true},
+		{"", 0, false},
+		{"d", 0, false},
+		{"10", 0, false},
+		{"10y", 0, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := parseRuleDuration(tt.input)
+			if (err == nil) != tt.valid {
+				t.Fatalf("expected valid %t, got error %v", tt.valid, err)
+			}
+
+			if tt.valid && d != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, d)
+			}
+		})
+	}
+}
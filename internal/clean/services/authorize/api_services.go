@@ -0,0 +1,143 @@
+package authorize
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/authorize"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentAuthorizeAPIServicesConfig struct {
+	Environment                 clean.CleanEnvironmentConfig
+	BootstrapAPIServiceNames    []string
+	BootstrapAPIServicePatterns []*regexp.Regexp
+	Rules                       []clean.Rule
+}
+
+func (c *CleanEnvironmentAuthorizeAPIServicesConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Authorize API Services"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapAPIServiceNames) == 0 && len(c.BootstrapAPIServicePatterns) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names, patterns or rules configured - skipping", configKey)
+		return nil
+	}
+
+	ok, err := clean.BillOfMaterialsHasService(ctx, configKey, c.Environment, management.ENUMPRODUCTTYPE_ONE_AUTHORIZE)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		l.Info().Msgf("[%s] Bill of materials does not contain applicable service %s - skipping", configKey, management.ENUMPRODUCTTYPE_ONE_AUTHORIZE)
+		return nil
+	}
+
+	var response *authorize.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.AuthorizeAPIClient.APIServersApi.ReadAllAPIServers(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasApiServers() {
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, apiServer := range embedded.GetApiServers() {
+			apiServer := apiServer
+
+			err := clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: apiServer.GetName(),
+					Id:                   apiServer.GetId(),
+					Object:               apiServer,
+					Impact: func() (*string, error) {
+						if len(apiServer.GetOperations()) == 0 {
+							return nil, nil
+						}
+
+						impact := fmt.Sprintf("API operations %s", strings.Join(operationNames(apiServer), ", "))
+						return &impact, nil
+					},
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapAPIServiceNames,
+					PatternListToSearch:    c.BootstrapAPIServicePatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+				},
+				func() (any, *http.Response, error) {
+					if err := c.deleteAPIServiceOperations(ctx, configKey, apiServer); err != nil {
+						return nil, nil, err
+					}
+
+					fR, fErr := c.Environment.Client.AuthorizeAPIClient.APIServersApi.DeleteAPIServer(ctx, c.Environment.EnvironmentID, apiServer.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+// deleteAPIServiceOperations removes the operations of an API service before the API service itself is removed.  The SDK holds operations on the API service, so they are removed by updating it with an empty set.
+func (c *CleanEnvironmentAuthorizeAPIServicesConfig) deleteAPIServiceOperations(ctx context.Context, configKey string, apiServer authorize.APIServer) error {
+	if len(apiServer.GetOperations()) == 0 {
+		return nil
+	}
+
+	withoutOperations := apiServer
+	withoutOperations.Operations = map[string]interface{}{}
+
+	return sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.AuthorizeAPIClient.APIServersApi.UpdateAPIServer(ctx, c.Environment.EnvironmentID, apiServer.GetId()).APIServer(withoutOperations).Execute()
+		},
+		fmt.Sprintf("[%s]-DELETEOPERATIONS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		nil,
+	)
+}
+
+func operationNames(apiServer authorize.APIServer) []string {
+	names := make([]string, 0, len(apiServer.GetOperations()))
+	for name := range apiServer.GetOperations() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,104 @@
+package authorize
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/authorize"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+)
+
+type CleanEnvironmentAuthorizeDecisionEndpointsConfig struct {
+	Environment                       clean.CleanEnvironmentConfig
+	BootstrapDecisionEndpointNames    []string
+	BootstrapDecisionEndpointPatterns []*regexp.Regexp
+	Rules                             []clean.Rule
+}
+
+func (c *CleanEnvironmentAuthorizeDecisionEndpointsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Authorize Decision Endpoints"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapDecisionEndpointNames) == 0 && len(c.BootstrapDecisionEndpointPatterns) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names, patterns or rules configured - skipping", configKey)
+		return nil
+	}
+
+	ok, err := clean.BillOfMaterialsHasService(ctx, configKey, c.Environment, management.ENUMPRODUCTTYPE_ONE_AUTHORIZE)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		l.Info().Msgf("[%s] Bill of materials does not contain applicable service %s - skipping", configKey, management.ENUMPRODUCTTYPE_ONE_AUTHORIZE)
+		return nil
+	}
+
+	var response *authorize.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.AuthorizeAPIClient.PolicyDecisionManagementApi.ReadAllDecisionEndpoints(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasDecisionEndpoints() {
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, decisionEndpoint := range embedded.GetDecisionEndpoints() {
+			decisionEndpoint := decisionEndpoint
+
+			// PingOne-owned endpoints are managed by the service that created them, such as an API service
+			if decisionEndpoint.GetOwned() {
+				l.Debug().Msgf(`[%s] Skipping PingOne-owned decision endpoint "%s"`, configKey, decisionEndpoint.GetName())
+				continue
+			}
+
+			err := clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: decisionEndpoint.GetName(),
+					Id:                   decisionEndpoint.GetId(),
+					Description:          &decisionEndpoint.Description,
+					Object:               decisionEndpoint,
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapDecisionEndpointNames,
+					PatternListToSearch:    c.BootstrapDecisionEndpointPatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+				},
+				func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.AuthorizeAPIClient.PolicyDecisionManagementApi.DeleteDecisionEndpoint(ctx, c.Environment.EnvironmentID, decisionEndpoint.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
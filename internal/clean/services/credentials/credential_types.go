@@ -0,0 +1,198 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentCredentialTypesConfig struct {
+	Environment                     clean.CleanEnvironmentConfig
+	BootstrapCredentialTypeNames    []string
+	BootstrapCredentialTypePatterns []*regexp.Regexp
+	Rules                           []clean.Rule
+}
+
+// issuedCredentials is the usage of one or more credential issuance rules
+type issuedCredentials struct {
+	rules   int
+	issued  int32
+	revoked int32
+}
+
+func (c *CleanEnvironmentCredentialTypesConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Credential Types"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapCredentialTypeNames) == 0 && len(c.BootstrapCredentialTypePatterns) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names, patterns or rules configured - skipping", configKey)
+		return nil
+	}
+
+	ok, err := clean.BillOfMaterialsHasService(ctx, configKey, c.Environment, management.ENUMPRODUCTTYPE_ONE_CREDENTIALS)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		l.Info().Msgf("[%s] Bill of materials does not contain applicable service %s - skipping", configKey, management.ENUMPRODUCTTYPE_ONE_CREDENTIALS)
+		return nil
+	}
+
+	var response *credentials.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.CredentialsAPIClient.CredentialTypesApi.ReadAllCredentialTypes(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasItems() {
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, item := range embedded.GetItems() {
+			if item.CredentialType == nil {
+				continue
+			}
+
+			credentialType := *item.CredentialType
+
+			// Deleting a credential type is a soft delete, so types that are already deleted are still returned
+			if credentialType.DeletedAt != nil {
+				continue
+			}
+
+			err := clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: credentialType.GetTitle(),
+					Id:                   credentialType.GetId(),
+					Description:          credentialType.Description,
+					Object:               credentialType,
+					CreatedAt:            credentialType.CreatedAt,
+					Impact: func() (*string, error) {
+						rules, err := readCredentialIssuanceRules(ctx, configKey, c.Environment, credentialType.GetId())
+						if err != nil {
+							return nil, err
+						}
+
+						usage, err := readIssuedCredentials(ctx, configKey, c.Environment, credentialType.GetId(), rules)
+						if err != nil {
+							return nil, err
+						}
+
+						if usage.rules == 0 && usage.issued == 0 {
+							return nil, nil
+						}
+
+						impact := usage.String()
+
+						if onDelete, ok := credentialType.GetOnDeleteOk(); ok && onDelete.GetRevokeIssuedCredentials() {
+							impact = fmt.Sprintf("%s, which are revoked when the credential type is deleted", impact)
+						}
+
+						return &impact, nil
+					},
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapCredentialTypeNames,
+					PatternListToSearch:    c.BootstrapCredentialTypePatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+				},
+				func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.CredentialsAPIClient.CredentialTypesApi.DeleteCredentialType(ctx, c.Environment.EnvironmentID, credentialType.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+func (u issuedCredentials) String() string {
+	return fmt.Sprintf("%d issued credentials (%d revoked) from %d credential issuance rules", u.issued, u.revoked, u.rules)
+}
+
+func readCredentialIssuanceRules(ctx context.Context, configKey string, environment clean.CleanEnvironmentConfig, credentialTypeID string) ([]credentials.CredentialIssuanceRule, error) {
+	var response *credentials.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return environment.Client.CredentialsAPIClient.CredentialIssuanceRulesApi.ReadAllCredentialIssuanceRules(ctx, environment.EnvironmentID, credentialTypeID).Execute()
+		},
+		fmt.Sprintf("[%s]-READISSUANCERULES", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok {
+		return embedded.GetIssuanceRules(), nil
+	}
+
+	return make([]credentials.CredentialIssuanceRule, 0), nil
+}
+
+// readIssuedCredentials totals the credentials issued and revoked by the credential issuance rules of a credential type
+func readIssuedCredentials(ctx context.Context, configKey string, environment clean.CleanEnvironmentConfig, credentialTypeID string, rules []credentials.CredentialIssuanceRule) (*issuedCredentials, error) {
+	usage := &issuedCredentials{}
+
+	for _, rule := range rules {
+		rule := rule
+
+		var counts *credentials.CredentialIssuanceRuleUsageCounts
+		err := sdk.ParseResponse(
+			ctx,
+			func() (any, *http.Response, error) {
+				return environment.Client.CredentialsAPIClient.CredentialIssuanceRulesApi.ReadCredentialIssuanceRuleUsageCounts(ctx, environment.EnvironmentID, credentialTypeID, rule.GetId()).Execute()
+			},
+			fmt.Sprintf("[%s]-READISSUANCERULEUSAGE", configKey),
+			sdk.DefaultCreateReadRetryable,
+			&counts,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		usage.rules++
+
+		if counts != nil {
+			usage.issued += counts.GetIssued()
+			usage.revoked += counts.GetRevoked()
+		}
+	}
+
+	return usage, nil
+}
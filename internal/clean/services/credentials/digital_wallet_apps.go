@@ -0,0 +1,179 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentDigitalWalletAppsConfig struct {
+	Environment                       clean.CleanEnvironmentConfig
+	BootstrapDigitalWalletAppNames    []string
+	BootstrapDigitalWalletAppPatterns []*regexp.Regexp
+	Rules                             []clean.Rule
+}
+
+func (c *CleanEnvironmentDigitalWalletAppsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Digital Wallet Apps"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapDigitalWalletAppNames) == 0 && len(c.BootstrapDigitalWalletAppPatterns) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names, patterns or rules configured - skipping", configKey)
+		return nil
+	}
+
+	ok, err := clean.BillOfMaterialsHasService(ctx, configKey, c.Environment, management.ENUMPRODUCTTYPE_ONE_CREDENTIALS)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		l.Info().Msgf("[%s] Bill of materials does not contain applicable service %s - skipping", configKey, management.ENUMPRODUCTTYPE_ONE_CREDENTIALS)
+		return nil
+	}
+
+	var response *credentials.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.CredentialsAPIClient.DigitalWalletAppsApi.ReadAllDigitalWalletApps(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasDigitalWalletApplications() {
+
+		var issuanceRules map[string]map[string][]credentials.CredentialIssuanceRule
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, digitalWalletApp := range embedded.GetDigitalWalletApplications() {
+			digitalWalletApp := digitalWalletApp
+
+			err := clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: digitalWalletApp.GetName(),
+					Id:                   digitalWalletApp.GetId(),
+					Object:               digitalWalletApp,
+					CreatedAt:            digitalWalletApp.CreatedAt,
+					Impact: func() (*string, error) {
+						if issuanceRules == nil {
+							var err error
+							issuanceRules, err = c.readDigitalWalletAppIssuanceRules(ctx, configKey)
+							if err != nil {
+								return nil, err
+							}
+						}
+
+						usage := &issuedCredentials{}
+						for credentialTypeID, rules := range issuanceRules[digitalWalletApp.GetId()] {
+							credentialTypeUsage, err := readIssuedCredentials(ctx, configKey, c.Environment, credentialTypeID, rules)
+							if err != nil {
+								return nil, err
+							}
+
+							usage.rules += credentialTypeUsage.rules
+							usage.issued += credentialTypeUsage.issued
+							usage.revoked += credentialTypeUsage.revoked
+						}
+
+						if usage.rules == 0 {
+							return nil, nil
+						}
+
+						impact := usage.String()
+						return &impact, nil
+					},
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapDigitalWalletAppNames,
+					PatternListToSearch:    c.BootstrapDigitalWalletAppPatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+				},
+				func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.CredentialsAPIClient.DigitalWalletAppsApi.DeleteDigitalWalletApp(ctx, c.Environment.EnvironmentID, digitalWalletApp.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+// readDigitalWalletAppIssuanceRules returns the credential issuance rules that issue to each digital wallet app, keyed by digital wallet app ID and then by credential type ID
+func (c *CleanEnvironmentDigitalWalletAppsConfig) readDigitalWalletAppIssuanceRules(ctx context.Context, configKey string) (map[string]map[string][]credentials.CredentialIssuanceRule, error) {
+	issuanceRules := make(map[string]map[string][]credentials.CredentialIssuanceRule)
+
+	var response *credentials.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.CredentialsAPIClient.CredentialTypesApi.ReadAllCredentialTypes(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		fmt.Sprintf("[%s]-READCREDENTIALTYPES", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok {
+		return issuanceRules, nil
+	}
+
+	for _, item := range embedded.GetItems() {
+		if item.CredentialType == nil || item.CredentialType.DeletedAt != nil {
+			continue
+		}
+
+		credentialTypeID := item.CredentialType.GetId()
+
+		rules, err := readCredentialIssuanceRules(ctx, configKey, c.Environment, credentialTypeID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rule := range rules {
+			if v, ok := rule.GetDigitalWalletApplicationOk(); ok {
+				if _, ok := issuanceRules[v.GetId()]; !ok {
+					issuanceRules[v.GetId()] = make(map[string][]credentials.CredentialIssuanceRule)
+				}
+
+				issuanceRules[v.GetId()][credentialTypeID] = append(issuanceRules[v.GetId()][credentialTypeID], rule)
+			}
+		}
+	}
+
+	return issuanceRules, nil
+}
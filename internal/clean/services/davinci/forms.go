@@ -22,6 +22,7 @@ var (
 type CleanEnvironmentDaVinciFormsConfig struct {
 	Environment               clean.CleanEnvironmentConfig
 	BootstrapDaVinciFormNames []string
+	Rules                     []clean.Rule
 }
 
 func (c *CleanEnvironmentDaVinciFormsConfig) Clean(ctx context.Context) error {
@@ -31,8 +32,8 @@ func (c *CleanEnvironmentDaVinciFormsConfig) Clean(ctx context.Context) error {
 
 	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
 
-	if len(c.BootstrapDaVinciFormNames) == 0 {
-		l.Info().Msgf("[%s] No bootstrap names configured - skipping", configKey)
+	if len(c.BootstrapDaVinciFormNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
 		return nil
 	}
 
@@ -64,6 +65,7 @@ func (c *CleanEnvironmentDaVinciFormsConfig) Clean(ctx context.Context) error {
 
 		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
 		for _, form := range embedded.GetForms() {
+			form := form
 
 			err := clean.TryCleanConfig(
 				ctx,
@@ -72,9 +74,12 @@ func (c *CleanEnvironmentDaVinciFormsConfig) Clean(ctx context.Context) error {
 				clean.ConfigItem{
 					IdentifierToEvaluate: form.Name,
 					Id:                   *form.Id,
+					Description:          form.Description,
+					Object:               form,
 				},
 				clean.ConfigItemEval{
 					IdentifierListToSearch: c.BootstrapDaVinciFormNames,
+					Rules:                  c.Rules,
 					StartsWithStringMatch:  false,
 				},
 				func() (any, *http.Response, error) {
@@ -14,11 +14,15 @@ var (
 	BootstrapMFADevicePolicyNames = []string{
 		"Default MFA Policy",
 	}
+
+	BootstrapMFADevicePolicyFingerprints = map[string]string{}
 )
 
 type CleanEnvironmentPlatformMFADevicePoliciesConfig struct {
-	Environment                   clean.CleanEnvironmentConfig
-	BootstrapMFADevicePolicyNames []string
+	Environment                          clean.CleanEnvironmentConfig
+	BootstrapMFADevicePolicyNames        []string
+	BootstrapMFADevicePolicyFingerprints map[string]string
+	Rules                                []clean.Rule
 }
 
 func (c *CleanEnvironmentPlatformMFADevicePoliciesConfig) Clean(ctx context.Context) error {
@@ -28,8 +32,8 @@ func (c *CleanEnvironmentPlatformMFADevicePoliciesConfig) Clean(ctx context.Cont
 
 	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
 
-	if len(c.BootstrapMFADevicePolicyNames) == 0 {
-		l.Info().Msgf("[%s] No bootstrap names configured - skipping", configKey)
+	if len(c.BootstrapMFADevicePolicyNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
 		return nil
 	}
 
@@ -61,19 +65,29 @@ func (c *CleanEnvironmentPlatformMFADevicePoliciesConfig) Clean(ctx context.Cont
 
 		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
 		for _, policy := range embedded.GetDeviceAuthenticationPolicies() {
+			policy := policy
+
+			fingerprint, err := clean.Fingerprint(policy, "name", "default")
+			if err != nil {
+				return err
+			}
 
-			err := clean.TryCleanConfig(
+			err = clean.TryCleanConfig(
 				ctx,
 				configKey,
 				c.Environment,
 				clean.ConfigItem{
 					IdentifierToEvaluate: policy.Name,
 					Id:                   *policy.Id,
+					Object:               policy,
 					Default:              &policy.Default,
+					Fingerprint:          &fingerprint,
 				},
 				clean.ConfigItemEval{
 					IdentifierListToSearch: c.BootstrapMFADevicePolicyNames,
+					Rules:                  c.Rules,
 					StartsWithStringMatch:  false,
+					BootstrapFingerprints:  c.BootstrapMFADevicePolicyFingerprints,
 				},
 				func() (any, *http.Response, error) {
 					fR, fErr := c.Environment.Client.MFAAPIClient.DeviceAuthenticationPolicyApi.DeleteDeviceAuthenticationPolicy(ctx, c.Environment.EnvironmentID, policy.GetId()).Execute()
@@ -20,6 +20,7 @@ var (
 type CleanEnvironmentPlatformMFAFIDO2PoliciesConfig struct {
 	Environment                  clean.CleanEnvironmentConfig
 	BootstrapMFAFIDO2PolicyNames []string
+	Rules                        []clean.Rule
 }
 
 func (c *CleanEnvironmentPlatformMFAFIDO2PoliciesConfig) Clean(ctx context.Context) error {
@@ -29,8 +30,8 @@ func (c *CleanEnvironmentPlatformMFAFIDO2PoliciesConfig) Clean(ctx context.Conte
 
 	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
 
-	if len(c.BootstrapMFAFIDO2PolicyNames) == 0 {
-		l.Info().Msgf("[%s] No bootstrap names configured - skipping", configKey)
+	if len(c.BootstrapMFAFIDO2PolicyNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
 		return nil
 	}
 
@@ -62,6 +63,7 @@ func (c *CleanEnvironmentPlatformMFAFIDO2PoliciesConfig) Clean(ctx context.Conte
 
 		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
 		for _, policy := range embedded.GetFido2Policies() {
+			policy := policy
 
 			err := clean.TryCleanConfig(
 				ctx,
@@ -70,10 +72,14 @@ func (c *CleanEnvironmentPlatformMFAFIDO2PoliciesConfig) Clean(ctx context.Conte
 				clean.ConfigItem{
 					IdentifierToEvaluate: policy.Name,
 					Id:                   *policy.Id,
+					Description:          policy.Description,
+					Object:               policy,
 					Default:              policy.Default,
+					CreatedAt:            policy.CreatedAt,
 				},
 				clean.ConfigItemEval{
 					IdentifierListToSearch: c.BootstrapMFAFIDO2PolicyNames,
+					Rules:                  c.Rules,
 					StartsWithStringMatch:  false,
 				},
 				func() (any, *http.Response, error) {
@@ -0,0 +1,75 @@
+package mfa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/mfa"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+)
+
+type CleanEnvironmentMFASettingsConfig struct {
+	Environment  clean.CleanEnvironmentConfig
+	DesiredState map[string]any
+}
+
+func (c *CleanEnvironmentMFASettingsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "MFA Settings"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.DesiredState) == 0 {
+		l.Info().Msgf("[%s] No desired state configured - skipping", configKey)
+		return nil
+	}
+
+	var response *mfa.MFASettings
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.MFAAPIClient.MFASettingsApi.ReadMFASettings(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if response == nil {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+		return nil
+	}
+
+	var desiredSettings mfa.MFASettings
+	if err := clean.MergeSettings(response, c.DesiredState, &desiredSettings); err != nil {
+		return fmt.Errorf("[%s] Cannot apply the desired state: %w", configKey, err)
+	}
+
+	err = clean.TryResetConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		clean.ConfigItem{
+			IdentifierToEvaluate: "MFA settings",
+			Id:                   c.Environment.EnvironmentID,
+			Object:               response,
+		},
+		c.DesiredState,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.MFAAPIClient.MFASettingsApi.UpdateMFASettings(ctx, c.Environment.EnvironmentID).MFASettings(desiredSettings).Execute()
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	l.Debug().Msgf("[%s] Done", configKey)
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+)
+
+type CleanEnvironmentPlatformBrandingSettingsConfig struct {
+	Environment  clean.CleanEnvironmentConfig
+	DesiredState map[string]any
+}
+
+func (c *CleanEnvironmentPlatformBrandingSettingsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Branding Settings"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.DesiredState) == 0 {
+		l.Info().Msgf("[%s] No desired state configured - skipping", configKey)
+		return nil
+	}
+
+	var response *management.BrandingSettings
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.BrandingSettingsApi.ReadBrandingSettings(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if response == nil {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+		return nil
+	}
+
+	var desiredSettings management.BrandingSettings
+	if err := clean.MergeSettings(response, c.DesiredState, &desiredSettings); err != nil {
+		return fmt.Errorf("[%s] Cannot apply the desired state: %w", configKey, err)
+	}
+
+	err = clean.TryResetConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		clean.ConfigItem{
+			IdentifierToEvaluate: "Branding settings",
+			Id:                   c.Environment.EnvironmentID,
+			Object:               response,
+		},
+		c.DesiredState,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.BrandingSettingsApi.UpdateBrandingSettings(ctx, c.Environment.EnvironmentID).BrandingSettings(desiredSettings).Execute()
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	l.Debug().Msgf("[%s] Done", configKey)
+
+	return nil
+}
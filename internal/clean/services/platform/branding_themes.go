@@ -18,6 +18,7 @@ var (
 type CleanEnvironmentPlatformBrandingThemesConfig struct {
 	Environment                 clean.CleanEnvironmentConfig
 	BootstrapBrandingThemeNames []string
+	Rules                       []clean.Rule
 }
 
 func (c *CleanEnvironmentPlatformBrandingThemesConfig) Clean(ctx context.Context) error {
@@ -27,8 +28,8 @@ func (c *CleanEnvironmentPlatformBrandingThemesConfig) Clean(ctx context.Context
 
 	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
 
-	if len(c.BootstrapBrandingThemeNames) == 0 {
-		l.Info().Msgf("[%s] No bootstrap names configured - skipping", configKey)
+	if len(c.BootstrapBrandingThemeNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
 		return nil
 	}
 
@@ -50,18 +51,22 @@ func (c *CleanEnvironmentPlatformBrandingThemesConfig) Clean(ctx context.Context
 
 		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
 		for _, theme := range embedded.GetThemes() {
+			theme := theme
 
 			err := clean.TryCleanConfig(
 				ctx,
 				configKey,
 				c.Environment,
+				// Themes have no description, so the protection marker can only be set in the theme name
 				clean.ConfigItem{
 					IdentifierToEvaluate: *theme.Configuration.Name,
 					Id:                   *theme.Id,
+					Object:               theme,
 					Default:              &theme.Default,
 				},
 				clean.ConfigItemEval{
 					IdentifierListToSearch: c.BootstrapBrandingThemeNames,
+					Rules:                  c.Rules,
 					StartsWithStringMatch:  false,
 				},
 				func() (any, *http.Response, error) {
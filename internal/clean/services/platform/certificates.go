@@ -0,0 +1,228 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentPlatformCertificatesConfig struct {
+	Environment        clean.CleanEnvironmentConfig
+	Expired            bool
+	ExpiringWithinDays int
+	Rules              []clean.Rule
+}
+
+func (c *CleanEnvironmentPlatformCertificatesConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	keysConfigKey := "Certificate Keys"
+	certificatesConfigKey := "Certificates"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, certificatesConfigKey, c.Environment.EnvironmentID)
+
+	if !c.Expired && c.ExpiringWithinDays <= 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No expiry criteria or rules configured - skipping", certificatesConfigKey)
+		return nil
+	}
+
+	now := time.Now()
+
+	err := c.cleanCertificates(
+		ctx,
+		keysConfigKey,
+		now,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.CertificateManagementApi.GetKeys(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		func(embedded *management.EntityArrayEmbedded) []management.Certificate {
+			return embedded.GetKeys()
+		},
+		func(id string) (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.CertificateManagementApi.GetKeyApplications(ctx, c.Environment.EnvironmentID, id).Execute()
+		},
+		func(id string) (*http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.CertificateManagementApi.DeleteKey(ctx, c.Environment.EnvironmentID, id).Execute()
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.cleanCertificates(
+		ctx,
+		certificatesConfigKey,
+		now,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.CertificateManagementApi.GetCertificates(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		func(embedded *management.EntityArrayEmbedded) []management.Certificate {
+			return embedded.GetCertificates()
+		},
+		func(id string) (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.CertificateManagementApi.GetCertificateApplications(ctx, c.Environment.EnvironmentID, id).Execute()
+		},
+		func(id string) (*http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.CertificateManagementApi.DeleteCertificate(ctx, c.Environment.EnvironmentID, id).Execute()
+		},
+	)
+}
+
+// cleanCertificates evaluates the keys or certificates returned by readAll against the expiry criteria.  Keys and certificates share a model, so only the SDK functions differ.
+func (c *CleanEnvironmentPlatformCertificatesConfig) cleanCertificates(ctx context.Context, configKey string, now time.Time, readAll sdk.SDKInterfaceFunc, items func(*management.EntityArrayEmbedded) []management.Certificate, readApplications func(string) (any, *http.Response, error), deleteByID func(string) (*http.Response, error)) error {
+	l := logger.Get()
+
+	var response *management.EntityArray
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		readAll,
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok || len(items(embedded)) == 0 {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+		return nil
+	}
+
+	var expiringCutoff *time.Time
+	if c.ExpiringWithinDays > 0 {
+		cutoff := now.AddDate(0, 0, c.ExpiringWithinDays)
+		expiringCutoff = &cutoff
+	}
+
+	l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+	for _, certificate := range items(embedded) {
+		certificate := certificate
+
+		validity := validitySummary(certificate, now)
+		l.Debug().Msgf(`[%s] "%s" is %s`, configKey, certificate.GetName(), validity)
+
+		expired := certificate.ExpiresAt != nil && certificate.ExpiresAt.Before(now)
+		expiring := certificate.ExpiresAt != nil && !expired && expiringCutoff != nil && certificate.ExpiresAt.Before(*expiringCutoff)
+
+		err := clean.TryCleanConfig(
+			ctx,
+			configKey,
+			c.Environment,
+			clean.ConfigItem{
+				IdentifierToEvaluate: certificate.GetName(),
+				Id:                   certificate.GetId(),
+				Object:               certificate,
+				Default:              certificate.Default,
+				CreatedAt:            certificate.CreatedAt,
+				BlockedBy: func() (*string, error) {
+					return usedByApplications(ctx, configKey, func() (any, *http.Response, error) {
+						return readApplications(certificate.GetId())
+					})
+				},
+			},
+			clean.ConfigItemEval{
+				Rules: c.Rules,
+				Selectors: []clean.ConfigItemSelector{
+					{
+						Name:    validity,
+						Matched: c.Expired && expired,
+					},
+					{
+						Name:    fmt.Sprintf("%s, within %d days", validity, c.ExpiringWithinDays),
+						Matched: expiring,
+					},
+				},
+			},
+			func() (any, *http.Response, error) {
+				fR, fErr := deleteByID(certificate.GetId())
+				return nil, fR, fErr
+			},
+			nil,
+		)
+
+		if err != nil {
+			return err
+		}
+
+	}
+	l.Debug().Msgf("[%s] Done", configKey)
+
+	return nil
+}
+
+// validitySummary describes the validity period of the key or certificate, including its not-after date
+func validitySummary(certificate management.Certificate, now time.Time) string {
+	if certificate.ExpiresAt == nil {
+		return "valid with no expiry date"
+	}
+
+	notAfter := certificate.ExpiresAt.Format(time.RFC3339)
+
+	notBefore := "unknown"
+	if certificate.StartsAt != nil {
+		notBefore = certificate.StartsAt.Format(time.RFC3339)
+	}
+
+	if certificate.ExpiresAt.Before(now) {
+		return fmt.Sprintf("expired (not before %s, not after %s)", notBefore, notAfter)
+	}
+
+	return fmt.Sprintf("expiring in %d days (not before %s, not after %s)", int(certificate.ExpiresAt.Sub(now).Hours()/24), notBefore, notAfter)
+}
+
+// usedByApplications returns the reason a key or certificate is blocked because applications use it, or nil if no applications use it
+func usedByApplications(ctx context.Context, configKey string, readApplications sdk.SDKInterfaceFunc) (*string, error) {
+	var response *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		readApplications,
+		fmt.Sprintf("[%s]-READAPPLICATIONS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok || len(embedded.GetApplications()) == 0 {
+		return nil, nil
+	}
+
+	applicationNames := make([]string, 0)
+	for _, application := range embedded.GetApplications() {
+		name, err := applicationName(application)
+		if err != nil {
+			return nil, err
+		}
+		applicationNames = append(applicationNames, name)
+	}
+
+	reason := fmt.Sprintf(`it is used by applications "%s"`, strings.Join(applicationNames, `", "`))
+	return &reason, nil
+}
+
+// applicationName returns the name shared by all application types
+func applicationName(application management.ReadOneApplication200Response) (string, error) {
+	applicationBytes, err := json.Marshal(application)
+	if err != nil {
+		return "", err
+	}
+
+	var common management.Application
+	if err := json.Unmarshal(applicationBytes, &common); err != nil {
+		return "", err
+	}
+
+	return common.GetName(), nil
+}
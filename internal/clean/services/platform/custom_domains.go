@@ -0,0 +1,140 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+)
+
+type CleanEnvironmentPlatformCustomDomainsConfig struct {
+	Environment             clean.CleanEnvironmentConfig
+	BootstrapDomainPatterns []*regexp.Regexp
+	UnverifiedOlderThanDays int
+	Rules                   []clean.Rule
+}
+
+func (c *CleanEnvironmentPlatformCustomDomainsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Custom Domains"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapDomainPatterns) == 0 && c.UnverifiedOlderThanDays <= 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap patterns, unverified age or rules configured - skipping", configKey)
+		return nil
+	}
+
+	var rawResponse *http.Response
+	var response *management.EntityArray
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			fO, fR, fErr := c.Environment.Client.ManagementAPIClient.CustomDomainsApi.ReadAllDomains(ctx, c.Environment.EnvironmentID).Execute()
+			rawResponse = fR
+			return fO, fR, fErr
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	createdAt, err := clean.CreationTimes(rawResponse, "customDomains")
+	if err != nil {
+		return fmt.Errorf("[%s] Cannot read the creation time of custom domains: %w", configKey, err)
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasCustomDomains() {
+
+		unverifiedCutoff := daysAgo(c.UnverifiedOlderThanDays)
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, customDomain := range embedded.GetCustomDomains() {
+			customDomain := customDomain
+
+			unverified := false
+			if unverifiedCutoff != nil && customDomain.GetStatus() == management.ENUMCUSTOMDOMAINSTATUS_VERIFICATION_REQUIRED {
+				unverified = olderThan(configKey, customDomain.GetDomainName(), createdAt[customDomain.GetId()], *unverifiedCutoff)
+			}
+
+			err := clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: customDomain.GetDomainName(),
+					Id:                   customDomain.GetId(),
+					Object:               customDomain,
+					CreatedAt:            createdAt[customDomain.GetId()],
+					Impact: func() (*string, error) {
+						// An active custom domain is the one the environment's hosted pages are currently served from
+						if customDomain.GetStatus() != management.ENUMCUSTOMDOMAINSTATUS_ACTIVE {
+							return nil, nil
+						}
+
+						impact := fmt.Sprintf(`the custom domain "%s" that the environment currently uses`, customDomain.GetDomainName())
+						return &impact, nil
+					},
+				},
+				clean.ConfigItemEval{
+					PatternListToSearch:   c.BootstrapDomainPatterns,
+					Rules:                 c.Rules,
+					StartsWithStringMatch: false,
+					Selectors: []clean.ConfigItemSelector{
+						{
+							Name:    fmt.Sprintf("unverified for more than %d days", c.UnverifiedOlderThanDays),
+							Matched: unverified,
+						},
+					},
+				},
+				func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.ManagementAPIClient.CustomDomainsApi.DeleteDomain(ctx, c.Environment.EnvironmentID, customDomain.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+// daysAgo returns the time the number of days before now, or nil if the number of days is not positive
+func daysAgo(days int) *time.Time {
+	if days <= 0 {
+		return nil
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -days)
+	return &cutoff
+}
+
+// olderThan returns true if the item was created before the cutoff.  Items without a creation time are never considered old enough.
+func olderThan(configKey, identifier string, createdAt *time.Time, cutoff time.Time) bool {
+	l := logger.Get()
+
+	if createdAt == nil {
+		l.Warn().Msgf(`[%s] The creation time of "%s" is not available, its age cannot be determined`, configKey, identifier)
+		return false
+	}
+
+	return createdAt.Before(cutoff)
+}
@@ -34,6 +34,7 @@ type CleanEnvironmentPlatformDirectoryAttributeConfig struct {
 	Environment             clean.CleanEnvironmentConfig
 	BootstrapAttributeNames []string
 	SchemaName              *string
+	Rules                   []clean.Rule
 }
 
 func (c *CleanEnvironmentPlatformDirectoryAttributeConfig) Clean(ctx context.Context) error {
@@ -43,8 +44,8 @@ func (c *CleanEnvironmentPlatformDirectoryAttributeConfig) Clean(ctx context.Con
 
 	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
 
-	if len(c.BootstrapAttributeNames) == 0 {
-		l.Info().Msgf("[%s] No bootstrap names configured - skipping", configKey)
+	if len(c.BootstrapAttributeNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
 		return nil
 	}
 
@@ -95,10 +96,13 @@ func (c *CleanEnvironmentPlatformDirectoryAttributeConfig) Clean(ctx context.Con
 				clean.ConfigItem{
 					IdentifierToEvaluate: attribute.Name,
 					Id:                   *attribute.Id,
+					Description:          attribute.Description,
+					Object:               attribute,
 					Enabled:              &attribute.Enabled,
 				},
 				clean.ConfigItemEval{
 					IdentifierListToSearch: c.BootstrapAttributeNames,
+					Rules:                  c.Rules,
 					StartsWithStringMatch:  false,
 				},
 				nil,
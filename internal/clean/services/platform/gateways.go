@@ -0,0 +1,273 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentPlatformGatewaysConfig struct {
+	Environment              clean.CleanEnvironmentConfig
+	BootstrapGatewayNames    []string
+	BootstrapGatewayPatterns []*regexp.Regexp
+	NoHeartbeatDays          int
+	Rules                    []clean.Rule
+}
+
+func (c *CleanEnvironmentPlatformGatewaysConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Gateways"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapGatewayNames) == 0 && len(c.BootstrapGatewayPatterns) == 0 && c.NoHeartbeatDays <= 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names, patterns, heartbeat age or rules configured - skipping", configKey)
+		return nil
+	}
+
+	var rawResponse *http.Response
+	var response *management.EntityArray
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			fO, fR, fErr := c.Environment.Client.ManagementAPIClient.GatewaysApi.ReadAllGateways(ctx, c.Environment.EnvironmentID).Execute()
+			rawResponse = fR
+			return fO, fR, fErr
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	createdAt, err := clean.CreationTimes(rawResponse, "gateways")
+	if err != nil {
+		return fmt.Errorf("[%s] Cannot read the creation time of gateways: %w", configKey, err)
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasGateways() {
+
+		var heartbeatCutoff *time.Time
+		if c.NoHeartbeatDays > 0 {
+			cutoff := time.Now().AddDate(0, 0, -c.NoHeartbeatDays)
+			heartbeatCutoff = &cutoff
+		}
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, gateway := range embedded.GetGateways() {
+			gateway := gateway
+
+			common, err := gatewayCommon(gateway)
+			if err != nil {
+				return err
+			}
+
+			// Instances are read at most once per gateway, for either the heartbeat check or the preview
+			var instances []management.GatewayInstance
+			readInstances := func() ([]management.GatewayInstance, error) {
+				if instances == nil {
+					var err error
+					instances, err = c.readGatewayInstances(ctx, configKey, common.GetId())
+					if err != nil {
+						return nil, err
+					}
+				}
+				return instances, nil
+			}
+
+			noHeartbeat := false
+			if heartbeatCutoff != nil {
+				instances, err := readInstances()
+				if err != nil {
+					return err
+				}
+
+				lastHeartbeat, err := lastReportedAt(instances)
+				if err != nil {
+					return err
+				}
+
+				// A gateway that no instance has ever reported to is judged by its age instead, so that a newly created gateway isn't selected before
+				// it has been connected.  Gateways without a creation time are not selected.
+				if lastHeartbeat == nil {
+					gatewayCreatedAt := createdAt[common.GetId()]
+					noHeartbeat = gatewayCreatedAt != nil && gatewayCreatedAt.Before(*heartbeatCutoff)
+				} else {
+					noHeartbeat = lastHeartbeat.Before(*heartbeatCutoff)
+				}
+			}
+
+			err = clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: common.GetName(),
+					Id:                   common.GetId(),
+					Description:          common.Description,
+					Object:               gateway,
+					CreatedAt:            createdAt[common.GetId()],
+					Impact: func() (*string, error) {
+						instances, err := readInstances()
+						if err != nil {
+							return nil, err
+						}
+
+						connected := make([]string, 0)
+						for _, instance := range instances {
+							if instance.GetConnected() {
+								connected = append(connected, fmt.Sprintf("%s (version %s, last reported at %s)", instance.GetHostname(), instance.Version.GetVersionNumber(), instance.GetLastReportedAt()))
+							}
+						}
+
+						impact := "the gateway's credentials, which are revoked first"
+						if len(connected) > 0 {
+							impact = fmt.Sprintf("%s, and connected gateway instances %s", impact, strings.Join(connected, ", "))
+						}
+						return &impact, nil
+					},
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapGatewayNames,
+					PatternListToSearch:    c.BootstrapGatewayPatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+					Selectors: []clean.ConfigItemSelector{
+						{
+							Name:    fmt.Sprintf("no heartbeat for more than %d days", c.NoHeartbeatDays),
+							Matched: noHeartbeat,
+						},
+					},
+				},
+				func() (any, *http.Response, error) {
+					// Credentials are revoked first, so that connected instances can no longer authenticate
+					if err := c.revokeGatewayCredentials(ctx, configKey, common.GetId()); err != nil {
+						return nil, nil, err
+					}
+
+					fR, fErr := c.Environment.Client.ManagementAPIClient.GatewaysApi.DeleteGateway(ctx, c.Environment.EnvironmentID, common.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+func (c *CleanEnvironmentPlatformGatewaysConfig) readGatewayInstances(ctx context.Context, configKey, gatewayID string) ([]management.GatewayInstance, error) {
+	var response *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.GatewayInstancesApi.ReadAllGatewayInstances(ctx, c.Environment.EnvironmentID, gatewayID).Execute()
+		},
+		fmt.Sprintf("[%s]-READINSTANCES", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok {
+		return embedded.GetGatewayInstances(), nil
+	}
+
+	return []management.GatewayInstance{}, nil
+}
+
+func (c *CleanEnvironmentPlatformGatewaysConfig) revokeGatewayCredentials(ctx context.Context, configKey, gatewayID string) error {
+	var response *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.GatewayCredentialsApi.ReadAllGatewayCredentials(ctx, c.Environment.EnvironmentID, gatewayID).Execute()
+		},
+		fmt.Sprintf("[%s]-READCREDENTIALS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok {
+		return nil
+	}
+
+	for _, credential := range embedded.GetCredentials() {
+		credential := credential
+
+		err := sdk.ParseResponse(
+			ctx,
+			func() (any, *http.Response, error) {
+				fR, fErr := c.Environment.Client.ManagementAPIClient.GatewayCredentialsApi.DeleteGatewayCredential(ctx, c.Environment.EnvironmentID, gatewayID, credential.GetId()).Execute()
+				return nil, fR, fErr
+			},
+			fmt.Sprintf("[%s]-DELETECREDENTIAL", configKey),
+			sdk.DefaultCreateReadRetryable,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lastReportedAt returns the most recent heartbeat of the gateway instances, or nil if none have reported
+func lastReportedAt(instances []management.GatewayInstance) (*time.Time, error) {
+	var last *time.Time
+	for _, instance := range instances {
+		reportedAt, err := clean.ParseTimestamp(instance.LastReportedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if reportedAt != nil && (last == nil || reportedAt.After(*last)) {
+			last = reportedAt
+		}
+	}
+
+	return last, nil
+}
+
+// gatewayCommon returns the properties shared by all gateway types
+func gatewayCommon(gateway management.EntityArrayEmbeddedGatewaysInner) (*management.Gateway, error) {
+	gatewayBytes, err := json.Marshal(&gateway)
+	if err != nil {
+		return nil, err
+	}
+
+	var common management.Gateway
+	if err := json.Unmarshal(gatewayBytes, &common); err != nil {
+		return nil, err
+	}
+
+	return &common, nil
+}
@@ -19,6 +19,7 @@ type CleanEnvironmentPlatformKeysConfig struct {
 	Environment               clean.CleanEnvironmentConfig
 	BootstrapIssuerDNPrefixes []string
 	CaseSensitive             bool
+	Rules                     []clean.Rule
 }
 
 func (c *CleanEnvironmentPlatformKeysConfig) Clean(ctx context.Context) error {
@@ -28,8 +29,8 @@ func (c *CleanEnvironmentPlatformKeysConfig) Clean(ctx context.Context) error {
 
 	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
 
-	if len(c.BootstrapIssuerDNPrefixes) == 0 {
-		l.Info().Msgf("[%s] No bootstrap names configured - skipping", configKey)
+	if len(c.BootstrapIssuerDNPrefixes) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
 		return nil
 	}
 
@@ -51,6 +52,7 @@ func (c *CleanEnvironmentPlatformKeysConfig) Clean(ctx context.Context) error {
 
 		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
 		for _, key := range embedded.GetKeys() {
+			key := key
 
 			err := clean.TryCleanConfig(
 				ctx,
@@ -59,10 +61,18 @@ func (c *CleanEnvironmentPlatformKeysConfig) Clean(ctx context.Context) error {
 				clean.ConfigItem{
 					IdentifierToEvaluate: key.Name,
 					Id:                   *key.Id,
+					Object:               key,
 					Default:              key.Default,
+					CreatedAt:            key.CreatedAt,
+					BlockedBy: func() (*string, error) {
+						return usedByApplications(ctx, configKey, func() (any, *http.Response, error) {
+							return c.Environment.Client.ManagementAPIClient.CertificateManagementApi.GetKeyApplications(ctx, c.Environment.EnvironmentID, key.GetId()).Execute()
+						})
+					},
 				},
 				clean.ConfigItemEval{
 					IdentifierListToSearch: c.BootstrapIssuerDNPrefixes,
+					Rules:                  c.Rules,
 					StartsWithStringMatch:  true,
 					CaseSensitive:          &c.CaseSensitive,
 				},
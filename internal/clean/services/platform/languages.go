@@ -0,0 +1,123 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentPlatformLanguagesConfig struct {
+	Environment    clean.CleanEnvironmentConfig
+	DesiredLocales []string
+}
+
+func (c *CleanEnvironmentPlatformLanguagesConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Languages"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	// Without a desired list every language other than the default would be disabled
+	if len(c.DesiredLocales) == 0 {
+		l.Info().Msgf("[%s] No desired locales configured - skipping", configKey)
+		return nil
+	}
+
+	var response *management.EntityArray
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.LanguagesApi.ReadLanguages(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasLanguages() {
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, languageInner := range embedded.GetLanguages() {
+			if languageInner.Language == nil {
+				continue
+			}
+
+			language := *languageInner.Language
+
+			if clean.ContainsFold(c.DesiredLocales, language.GetLocale()) {
+				l.Debug().Msgf(`[%s] Keeping desired locale "%s"`, configKey, language.GetLocale())
+				continue
+			}
+
+			customerAdded := language.GetCustomerAdded()
+
+			// Most built-in languages are disabled from the start, so they are not reported individually
+			if !customerAdded && !language.GetEnabled() {
+				continue
+			}
+
+			var deleteFunc, disableFunc sdk.SDKInterfaceFunc
+			var enabled *bool
+			if customerAdded {
+				deleteFunc = func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.ManagementAPIClient.LanguagesApi.DeleteLanguage(ctx, c.Environment.EnvironmentID, language.GetId()).Execute()
+					return nil, fR, fErr
+				}
+			} else {
+				enabled = &language.Enabled
+				disableFunc = func() (any, *http.Response, error) {
+					return c.Environment.Client.ManagementAPIClient.LanguagesApi.UpdateLanguage(ctx, c.Environment.EnvironmentID, language.GetId()).Language(*management.NewLanguage(false, false, language.GetLocale())).Execute()
+				}
+			}
+
+			identifier := language.GetLocale()
+			if language.Name != nil {
+				identifier = fmt.Sprintf("%s (%s)", language.GetLocale(), language.GetName())
+			}
+
+			err := clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: identifier,
+					Id:                   language.GetId(),
+					Object:               language,
+					Default:              &language.Default,
+					Enabled:              enabled,
+					CreatedAt:            language.CreatedAt,
+				},
+				clean.ConfigItemEval{
+					Selectors: []clean.ConfigItemSelector{
+						{
+							Name:    "not a desired locale",
+							Matched: true,
+						},
+					},
+				},
+				deleteFunc,
+				disableFunc,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ var (
 type CleanEnvironmentPlatformNotificationPoliciesConfig struct {
 	Environment                      clean.CleanEnvironmentConfig
 	BootstrapNotificationPolicyNames []string
+	Rules                            []clean.Rule
 }
 
 func (c *CleanEnvironmentPlatformNotificationPoliciesConfig) Clean(ctx context.Context) error {
@@ -27,8 +28,8 @@ func (c *CleanEnvironmentPlatformNotificationPoliciesConfig) Clean(ctx context.C
 
 	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
 
-	if len(c.BootstrapNotificationPolicyNames) == 0 {
-		l.Info().Msgf("[%s] No bootstrap names configured - skipping", configKey)
+	if len(c.BootstrapNotificationPolicyNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
 		return nil
 	}
 
@@ -50,6 +51,7 @@ func (c *CleanEnvironmentPlatformNotificationPoliciesConfig) Clean(ctx context.C
 
 		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
 		for _, policy := range embedded.GetNotificationsPolicies() {
+			policy := policy
 
 			err := clean.TryCleanConfig(
 				ctx,
@@ -58,10 +60,13 @@ func (c *CleanEnvironmentPlatformNotificationPoliciesConfig) Clean(ctx context.C
 				clean.ConfigItem{
 					IdentifierToEvaluate: policy.Name,
 					Id:                   *policy.Id,
+					Object:               policy,
 					Default:              policy.Default,
+					CreatedAt:            policy.CreatedAt,
 				},
 				clean.ConfigItemEval{
 					IdentifierListToSearch: c.BootstrapNotificationPolicyNames,
+					Rules:                  c.Rules,
 					StartsWithStringMatch:  false,
 				},
 				func() (any, *http.Response, error) {
@@ -0,0 +1,170 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentPlatformNotificationTemplatesConfig struct {
+	Environment   clean.CleanEnvironmentConfig
+	TemplateNames []string
+	Locales       []string
+	Rules         []clean.Rule
+}
+
+func (c *CleanEnvironmentPlatformNotificationTemplatesConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Notification Templates"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.TemplateNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No template names or rules configured - skipping", configKey)
+		return nil
+	}
+
+	var response *management.EntityArray
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.NotificationsTemplatesApi.ReadAllTemplates(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasTemplates() {
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, template := range embedded.GetTemplates() {
+			template := template
+
+			templateSelected := clean.Contains(c.TemplateNames, template.GetId())
+
+			// Contents are only read for templates that can be selected
+			if !templateSelected && len(c.Rules) == 0 {
+				continue
+			}
+
+			contents, err := c.readTemplateContents(ctx, configKey, template.GetId())
+			if err != nil {
+				return err
+			}
+
+			for _, content := range contents {
+				content := content
+
+				common, err := templateContentCommon(content)
+				if err != nil {
+					return err
+				}
+
+				// PingOne default contents are what customised contents are reset to, so there is nothing to do for them
+				if common.GetDefault() {
+					continue
+				}
+
+				if len(c.Locales) > 0 && !clean.Contains(c.Locales, common.GetLocale()) {
+					continue
+				}
+
+				createdAt, err := clean.ParseTimestamp(common.CreatedAt)
+				if err != nil {
+					return err
+				}
+
+				identifier := strings.Join([]string{template.GetId(), string(common.GetDeliveryMethod()), common.GetLocale()}, "/")
+				if common.Variant != nil {
+					identifier = fmt.Sprintf("%s/%s", identifier, common.GetVariant())
+				}
+
+				err = clean.TryCleanConfig(
+					ctx,
+					configKey,
+					c.Environment,
+					clean.ConfigItem{
+						IdentifierToEvaluate: identifier,
+						Id:                   common.GetId(),
+						Object:               content,
+						CreatedAt:            createdAt,
+					},
+					clean.ConfigItemEval{
+						Rules: c.Rules,
+						Selectors: []clean.ConfigItemSelector{
+							{
+								Name:    fmt.Sprintf("template %s", template.GetId()),
+								Matched: templateSelected,
+							},
+						},
+					},
+					func() (any, *http.Response, error) {
+						fR, fErr := c.Environment.Client.ManagementAPIClient.NotificationsTemplatesApi.DeleteContent(ctx, c.Environment.EnvironmentID, template.GetId(), common.GetId()).Execute()
+						return nil, fR, fErr
+					},
+					nil,
+				)
+
+				if err != nil {
+					return err
+				}
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+func (c *CleanEnvironmentPlatformNotificationTemplatesConfig) readTemplateContents(ctx context.Context, configKey, templateName string) ([]management.TemplateContent, error) {
+	var response *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.NotificationsTemplatesApi.ReadAllTemplateContents(ctx, c.Environment.EnvironmentID, templateName).Execute()
+		},
+		fmt.Sprintf("[%s]-READCONTENTS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok {
+		return embedded.GetContents(), nil
+	}
+
+	return []management.TemplateContent{}, nil
+}
+
+// templateContentCommon returns the properties shared by the contents of all delivery methods
+func templateContentCommon(content management.TemplateContent) (*management.TemplateContentCommon, error) {
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var common management.TemplateContentCommon
+	if err := json.Unmarshal(contentBytes, &common); err != nil {
+		return nil, err
+	}
+
+	return &common, nil
+}
@@ -0,0 +1,221 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+var (
+	DefaultPlaceholderHosts = []string{
+		"webhook.site",
+		"localhost",
+		"127.0.0.1",
+		"example.com",
+	}
+)
+
+type CleanEnvironmentPlatformSubscriptionsConfig struct {
+	Environment                   clean.CleanEnvironmentConfig
+	BootstrapSubscriptionNames    []string
+	BootstrapSubscriptionPatterns []*regexp.Regexp
+	DestinationHosts              []string
+	PlaceholderHosts              []string
+	Disabled                      bool
+	Disable                       bool
+	Rules                         []clean.Rule
+}
+
+func (c *CleanEnvironmentPlatformSubscriptionsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Subscriptions"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	var response *management.EntityArray
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.SubscriptionsWebhooksApi.ReadAllSubscriptions(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	selectionConfigured := len(c.BootstrapSubscriptionNames) > 0 || len(c.BootstrapSubscriptionPatterns) > 0 || len(c.DestinationHosts) > 0 || c.Disabled || len(c.Rules) > 0
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasSubscriptions() {
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, subscription := range embedded.GetSubscriptions() {
+			subscription := subscription
+
+			// Insecure and placeholder destinations are reported whether or not the subscription is selected
+			host := ""
+			parsed := false
+			secure := true
+			if destination, err := url.Parse(subscription.HttpEndpoint.GetUrl()); err != nil {
+				l.Warn().Msgf(`[%s] Subscription "%s" has a destination URL that cannot be parsed: %s`, configKey, subscription.GetName(), err)
+			} else {
+				parsed = true
+				host = destination.Hostname()
+				secure = strings.EqualFold(destination.Scheme, "https")
+			}
+
+			destinationSelectors := []clean.ConfigItemSelector{
+				{
+					Name:    "destination URL cannot be parsed",
+					Matched: !parsed,
+				},
+				{
+					Name:    "non-HTTPS destination",
+					Matched: parsed && !secure,
+				},
+				{
+					Name:    "placeholder destination",
+					Matched: host != "" && matchesHost(c.PlaceholderHosts, host),
+				},
+			}
+
+			// A subscription is reported once, with every reason that applies to its destination
+			reasons := make([]string, 0)
+			for _, selector := range destinationSelectors {
+				if selector.Matched {
+					reasons = append(reasons, selector.Name)
+				}
+			}
+
+			if len(reasons) > 0 {
+				action := clean.ENUMCLEANOUTPUTACTION_DELETE
+				if c.Disable {
+					action = clean.ENUMCLEANOUTPUTRESULT_DISABLE
+				}
+
+				err := clean.ReportConfig(
+					configKey,
+					c.Environment,
+					clean.ConfigItem{
+						IdentifierToEvaluate: subscription.GetName(),
+						Id:                   subscription.GetId(),
+						Object:               subscription,
+					},
+					clean.ConfigItemEval{
+						Selectors: destinationSelectors,
+					},
+					action,
+					fmt.Sprintf(`the destination is "%s" (%s)`, subscription.HttpEndpoint.GetUrl(), strings.Join(reasons, ", ")),
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			if !selectionConfigured {
+				continue
+			}
+
+			var deleteFunc, disableFunc sdk.SDKInterfaceFunc
+			var enabled *bool
+			if c.Disable {
+				enabled = &subscription.Enabled
+				disableFunc = func() (any, *http.Response, error) {
+					return c.disableSubscription(ctx, subscription)
+				}
+			} else {
+				deleteFunc = func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.ManagementAPIClient.SubscriptionsWebhooksApi.DeleteSubscription(ctx, c.Environment.EnvironmentID, subscription.GetId()).Execute()
+					return nil, fR, fErr
+				}
+			}
+
+			err := clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: subscription.GetName(),
+					Id:                   subscription.GetId(),
+					Object:               subscription,
+					Enabled:              enabled,
+					CreatedAt:            subscription.CreatedAt,
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapSubscriptionNames,
+					PatternListToSearch:    c.BootstrapSubscriptionPatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+					Selectors: []clean.ConfigItemSelector{
+						{
+							Name:    fmt.Sprintf("destination host %s", host),
+							Matched: host != "" && matchesHost(c.DestinationHosts, host),
+						},
+						{
+							Name:    "disabled",
+							Matched: c.Disabled && !subscription.GetEnabled(),
+						},
+					},
+				},
+				deleteFunc,
+				disableFunc,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+// disableSubscription disables the subscription, sending back only its writable settings
+func (c *CleanEnvironmentPlatformSubscriptionsConfig) disableSubscription(ctx context.Context, subscription management.Subscription) (any, *http.Response, error) {
+
+	subscriptionMap, err := clean.WritableSettings(subscription)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subscriptionMap["enabled"] = false
+
+	updateBytes, err := json.Marshal(subscriptionMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var update management.Subscription
+	if err := json.Unmarshal(updateBytes, &update); err != nil {
+		return nil, nil, err
+	}
+
+	return c.Environment.Client.ManagementAPIClient.SubscriptionsWebhooksApi.UpdateSubscription(ctx, c.Environment.EnvironmentID, subscription.GetId()).Subscription(update).Execute()
+}
+
+// matchesHost returns true if the host is one of the listed hosts, or a subdomain of one
+func matchesHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if strings.EqualFold(host, h) || strings.HasSuffix(strings.ToLower(host), "."+strings.ToLower(h)) {
+			return true
+		}
+	}
+	return false
+}
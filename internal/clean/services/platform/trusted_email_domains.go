@@ -0,0 +1,144 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentPlatformTrustedEmailDomainsConfig struct {
+	Environment             clean.CleanEnvironmentConfig
+	BootstrapDomainPatterns []*regexp.Regexp
+	UnverifiedOlderThanDays int
+	Rules                   []clean.Rule
+}
+
+func (c *CleanEnvironmentPlatformTrustedEmailDomainsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Trusted Email Domains"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapDomainPatterns) == 0 && c.UnverifiedOlderThanDays <= 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap patterns, unverified age or rules configured - skipping", configKey)
+		return nil
+	}
+
+	var rawResponse *http.Response
+	var response *management.EntityArray
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			fO, fR, fErr := c.Environment.Client.ManagementAPIClient.TrustedEmailDomainsApi.ReadAllTrustedEmailDomains(ctx, c.Environment.EnvironmentID).Execute()
+			rawResponse = fR
+			return fO, fR, fErr
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	createdAt, err := clean.CreationTimes(rawResponse, "emailDomains")
+	if err != nil {
+		return fmt.Errorf("[%s] Cannot read the creation time of trusted email domains: %w", configKey, err)
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasEmailDomains() {
+
+		unverifiedCutoff := daysAgo(c.UnverifiedOlderThanDays)
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, emailDomain := range embedded.GetEmailDomains() {
+			emailDomain := emailDomain
+
+			unverified := false
+			if unverifiedCutoff != nil && olderThan(configKey, emailDomain.GetDomainName(), createdAt[emailDomain.GetId()], *unverifiedCutoff) {
+				verified, err := c.emailDomainVerified(ctx, configKey, emailDomain.GetId())
+				if err != nil {
+					return err
+				}
+
+				unverified = !verified
+			}
+
+			err := clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: emailDomain.GetDomainName(),
+					Id:                   emailDomain.GetId(),
+					Object:               emailDomain,
+					CreatedAt:            createdAt[emailDomain.GetId()],
+				},
+				clean.ConfigItemEval{
+					PatternListToSearch:   c.BootstrapDomainPatterns,
+					Rules:                 c.Rules,
+					StartsWithStringMatch: false,
+					Selectors: []clean.ConfigItemSelector{
+						{
+							Name:    fmt.Sprintf("unverified for more than %d days", c.UnverifiedOlderThanDays),
+							Matched: unverified,
+						},
+					},
+				},
+				func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.ManagementAPIClient.TrustedEmailDomainsApi.DeleteTrustedEmailDomain(ctx, c.Environment.EnvironmentID, emailDomain.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+// emailDomainVerified returns true if the ownership of the email domain has been verified in any of the environment's email sending regions
+func (c *CleanEnvironmentPlatformTrustedEmailDomainsConfig) emailDomainVerified(ctx context.Context, configKey, emailDomainID string) (bool, error) {
+	var response *management.EmailDomainOwnershipStatus
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.TrustedEmailDomainsApi.ReadTrustedEmailDomainOwnershipStatus(ctx, c.Environment.EnvironmentID, emailDomainID).Execute()
+		},
+		fmt.Sprintf("[%s]-READOWNERSHIPSTATUS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// A missing status is an error, rather than a sign that the domain is unverified
+	if response == nil {
+		return false, fmt.Errorf(`[%s] Cannot read the ownership status of trusted email domain ID "%s": the response is empty`, configKey, emailDomainID)
+	}
+
+	for _, region := range response.GetRegions() {
+		if region.GetStatus() == management.ENUMEMAILDOMAINSTATUS_ACTIVE {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
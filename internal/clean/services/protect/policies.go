@@ -14,11 +14,15 @@ var (
 	BootstrapRiskPolicyNames = []string{
 		"Default Risk Policy",
 	}
+
+	BootstrapRiskPolicyFingerprints = map[string]string{}
 )
 
 type CleanEnvironmentProtectRiskPoliciesConfig struct {
-	Environment              clean.CleanEnvironmentConfig
-	BootstrapRiskPolicyNames []string
+	Environment                     clean.CleanEnvironmentConfig
+	BootstrapRiskPolicyNames        []string
+	BootstrapRiskPolicyFingerprints map[string]string
+	Rules                           []clean.Rule
 }
 
 func (c *CleanEnvironmentProtectRiskPoliciesConfig) Clean(ctx context.Context) error {
@@ -28,8 +32,8 @@ func (c *CleanEnvironmentProtectRiskPoliciesConfig) Clean(ctx context.Context) e
 
 	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
 
-	if len(c.BootstrapRiskPolicyNames) == 0 {
-		l.Info().Msgf("[%s] No bootstrap names configured - skipping", configKey)
+	if len(c.BootstrapRiskPolicyNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
 		return nil
 	}
 
@@ -61,19 +65,31 @@ func (c *CleanEnvironmentProtectRiskPoliciesConfig) Clean(ctx context.Context) e
 
 		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
 		for _, policy := range embedded.GetRiskPolicySets() {
+			policy := policy
+
+			fingerprint, err := clean.Fingerprint(policy, "name", "description", "default")
+			if err != nil {
+				return err
+			}
 
-			err := clean.TryCleanConfig(
+			err = clean.TryCleanConfig(
 				ctx,
 				configKey,
 				c.Environment,
 				clean.ConfigItem{
 					IdentifierToEvaluate: policy.Name,
 					Id:                   *policy.Id,
+					Description:          policy.Description,
+					Object:               policy,
 					Default:              policy.Default,
+					Fingerprint:          &fingerprint,
+					CreatedAt:            policy.CreatedAt,
 				},
 				clean.ConfigItemEval{
 					IdentifierListToSearch: c.BootstrapRiskPolicyNames,
+					Rules:                  c.Rules,
 					StartsWithStringMatch:  false,
+					BootstrapFingerprints:  c.BootstrapRiskPolicyFingerprints,
 				},
 				func() (any, *http.Response, error) {
 					fR, fErr := c.Environment.Client.RiskAPIClient.RiskPoliciesApi.DeleteRiskPolicySet(ctx, c.Environment.EnvironmentID, policy.GetId()).Execute()
@@ -0,0 +1,244 @@
+package protect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/risk"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentProtectRiskPredictorsConfig struct {
+	Environment                    clean.CleanEnvironmentConfig
+	BootstrapRiskPredictorNames    []string
+	BootstrapRiskPredictorPatterns []*regexp.Regexp
+	Unreferenced                   bool
+	Rules                          []clean.Rule
+}
+
+// riskPolicySetReference is the part of a risk policy set that can refer to a predictor
+type riskPolicySetReference struct {
+	name                  string
+	evaluatedPredictorIDs []string
+	policies              string
+}
+
+func (c *CleanEnvironmentProtectRiskPredictorsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Risk Predictors"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	// Unreferenced predictors are always reported, so the service runs even if nothing is configured to be deleted
+	ok, err := clean.BillOfMaterialsHasService(ctx, configKey, c.Environment, management.ENUMPRODUCTTYPE_ONE_RISK)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		l.Info().Msgf("[%s] Bill of materials does not contain applicable service %s - skipping", configKey, management.ENUMPRODUCTTYPE_ONE_RISK)
+		return nil
+	}
+
+	var response *risk.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.RiskAPIClient.RiskAdvancedPredictorsApi.ReadAllRiskPredictors(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasRiskPredictors() {
+
+		policySets, err := c.readRiskPolicySetReferences(ctx, configKey)
+		if err != nil {
+			return err
+		}
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, predictor := range embedded.GetRiskPredictors() {
+			predictor := predictor
+
+			common, err := riskPredictorCommon(predictor)
+			if err != nil {
+				return err
+			}
+
+			// PingOne's built-in predictors cannot be deleted, so they are never modified
+			if !common.GetDeletable() {
+				l.Debug().Msgf(`[%s] Skipping built-in predictor "%s"`, configKey, common.GetName())
+				continue
+			}
+
+			referencingPolicySets := referencingRiskPolicySets(policySets, common.GetId(), common.GetCompactName())
+
+			configItem := clean.ConfigItem{
+				IdentifierToEvaluate: common.GetName(),
+				Id:                   common.GetId(),
+				Description:          common.Description,
+				Object:               predictor,
+				CreatedAt:            common.CreatedAt,
+				BlockedBy: func() (*string, error) {
+					if len(referencingPolicySets) == 0 {
+						return nil, nil
+					}
+
+					reason := fmt.Sprintf(`the predictor is used by risk policy sets "%s"`, strings.Join(referencingPolicySets, `", "`))
+					return &reason, nil
+				},
+			}
+
+			// Unreferenced predictors are flagged for review, and only deleted when the reference check is switched on
+			if len(referencingPolicySets) == 0 && !c.Unreferenced {
+				err := clean.ReportConfig(
+					configKey,
+					c.Environment,
+					configItem,
+					clean.ConfigItemEval{
+						Selectors: []clean.ConfigItemSelector{
+							{
+								Name:    "unreferenced",
+								Matched: true,
+							},
+						},
+					},
+					clean.ENUMCLEANOUTPUTACTION_DELETE,
+					"no risk policy set refers to the predictor",
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				configItem,
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapRiskPredictorNames,
+					PatternListToSearch:    c.BootstrapRiskPredictorPatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+					Selectors: []clean.ConfigItemSelector{
+						{
+							Name:    "unreferenced",
+							Matched: c.Unreferenced && len(referencingPolicySets) == 0,
+						},
+					},
+				},
+				func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.RiskAPIClient.RiskAdvancedPredictorsApi.DeleteRiskAdvancedPredictor(ctx, c.Environment.EnvironmentID, common.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+func (c *CleanEnvironmentProtectRiskPredictorsConfig) readRiskPolicySetReferences(ctx context.Context, configKey string) ([]riskPolicySetReference, error) {
+	var response *risk.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.RiskAPIClient.RiskPoliciesApi.ReadRiskPolicySets(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		fmt.Sprintf("[%s]-READPOLICYSETS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	references := make([]riskPolicySetReference, 0)
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok {
+		return references, nil
+	}
+
+	for _, policySet := range embedded.GetRiskPolicySets() {
+		evaluatedPredictorIDs := make([]string, 0)
+		for _, evaluatedPredictor := range policySet.GetEvaluatedPredictors() {
+			evaluatedPredictorIDs = append(evaluatedPredictorIDs, evaluatedPredictor.GetId())
+		}
+
+		// Policy conditions refer to predictors by compact name in their expressions, so they are searched as text
+		policiesBytes, err := json.Marshal(policySet.GetRiskPolicies())
+		if err != nil {
+			return nil, err
+		}
+
+		references = append(references, riskPolicySetReference{
+			name:                  policySet.GetName(),
+			evaluatedPredictorIDs: evaluatedPredictorIDs,
+			policies:              string(policiesBytes),
+		})
+	}
+
+	return references, nil
+}
+
+// referencingRiskPolicySets returns the names of the risk policy sets that evaluate the predictor or refer to it in a policy condition
+func referencingRiskPolicySets(policySets []riskPolicySetReference, predictorID, compactName string) []string {
+	compactNameReference := regexp.MustCompile(fmt.Sprintf(`\.%s\b`, regexp.QuoteMeta(compactName)))
+
+	names := make([]string, 0)
+	for _, policySet := range policySets {
+		referenced := compactNameReference.MatchString(policySet.policies)
+
+		for _, id := range policySet.evaluatedPredictorIDs {
+			if id == predictorID {
+				referenced = true
+			}
+		}
+
+		if referenced {
+			names = append(names, policySet.name)
+		}
+	}
+
+	return names
+}
+
+// riskPredictorCommon returns the properties shared by all predictor types
+func riskPredictorCommon(predictor risk.RiskPredictor) (*risk.RiskPredictorCommon, error) {
+	predictorBytes, err := json.Marshal(predictor)
+	if err != nil {
+		return nil, err
+	}
+
+	var common risk.RiskPredictorCommon
+	if err := json.Unmarshal(predictorBytes, &common); err != nil {
+		return nil, err
+	}
+
+	return &common, nil
+}
@@ -0,0 +1,323 @@
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentAgreementsConfig struct {
+	Environment                clean.CleanEnvironmentConfig
+	BootstrapAgreementNames    []string
+	BootstrapAgreementPatterns []*regexp.Regexp
+	Rules                      []clean.Rule
+}
+
+// agreementLanguageRevisions holds a language version of an agreement with its revisions
+type agreementLanguageRevisions struct {
+	language  management.AgreementLanguage
+	revisions []management.AgreementLanguageRevision
+}
+
+func (c *CleanEnvironmentAgreementsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Agreements"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapAgreementNames) == 0 && len(c.BootstrapAgreementPatterns) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names, patterns or rules configured - skipping", configKey)
+		return nil
+	}
+
+	var response *management.EntityArray
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.AgreementsResourcesApi.ReadAllAgreements(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasAgreements() {
+
+		var references map[string][]string
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, agreement := range embedded.GetAgreements() {
+			agreement := agreement
+
+			// Languages and revisions are read at most once per agreement, for either the preview or the delete
+			var languages []agreementLanguageRevisions
+			readLanguages := func() ([]agreementLanguageRevisions, error) {
+				if languages == nil {
+					var err error
+					languages, err = c.readAgreementLanguages(ctx, configKey, agreement.GetId())
+					if err != nil {
+						return nil, err
+					}
+				}
+				return languages, nil
+			}
+
+			err := clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: agreement.GetName(),
+					Id:                   agreement.GetId(),
+					Description:          agreement.Description,
+					Object:               agreement,
+					BlockedBy: func() (*string, error) {
+						if references == nil {
+							var err error
+							references, err = c.readSignOnPolicyAgreementReferences(ctx, configKey)
+							if err != nil {
+								return nil, err
+							}
+						}
+
+						if policies, ok := references[agreement.GetId()]; ok {
+							reason := fmt.Sprintf(`the agreement is used by the agreement actions of sign-on policies "%s"`, strings.Join(policies, `", "`))
+							return &reason, nil
+						}
+
+						return nil, nil
+					},
+					Impact: func() (*string, error) {
+						languages, err := readLanguages()
+						if err != nil {
+							return nil, err
+						}
+
+						affected := make([]string, 0)
+
+						if agreement.GetEnabled() {
+							affected = append(affected, "the agreement, which is disabled first")
+						}
+
+						if len(languages) > 0 {
+							languageSummaries := make([]string, 0, len(languages))
+							for _, language := range languages {
+								languageSummaries = append(languageSummaries, fmt.Sprintf("%s (%d revisions)", language.language.GetLocale(), len(language.revisions)))
+							}
+							affected = append(affected, fmt.Sprintf("agreement languages %s, which are deleted first with their revisions", strings.Join(languageSummaries, ", ")))
+						}
+
+						if agreement.GetTotalConsents() > 0 {
+							affected = append(affected, fmt.Sprintf("%d user consents", agreement.GetTotalConsents()))
+						}
+
+						if len(affected) == 0 {
+							return nil, nil
+						}
+
+						impact := strings.Join(affected, ", and ")
+						return &impact, nil
+					},
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapAgreementNames,
+					PatternListToSearch:    c.BootstrapAgreementPatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+				},
+				func() (any, *http.Response, error) {
+					languages, err := readLanguages()
+					if err != nil {
+						return nil, nil, err
+					}
+
+					if err := c.deleteAgreementDependents(ctx, configKey, agreement, languages); err != nil {
+						return nil, nil, err
+					}
+
+					fR, fErr := c.Environment.Client.ManagementAPIClient.AgreementsResourcesApi.DeleteAgreement(ctx, c.Environment.EnvironmentID, agreement.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+// deleteAgreementDependents disables the agreement, then removes its revisions and language versions.  The current revision of a language is removed with the language.
+func (c *CleanEnvironmentAgreementsConfig) deleteAgreementDependents(ctx context.Context, configKey string, agreement management.Agreement, languages []agreementLanguageRevisions) error {
+	if agreement.GetEnabled() {
+		// Only the writable settings are sent back
+		agreementMap, err := clean.WritableSettings(agreement)
+		if err != nil {
+			return err
+		}
+
+		agreementMap["enabled"] = false
+
+		updateBytes, err := json.Marshal(agreementMap)
+		if err != nil {
+			return err
+		}
+
+		var disabled management.Agreement
+		if err := json.Unmarshal(updateBytes, &disabled); err != nil {
+			return err
+		}
+
+		err = sdk.ParseResponse(
+			ctx,
+			func() (any, *http.Response, error) {
+				return c.Environment.Client.ManagementAPIClient.AgreementsResourcesApi.UpdateAgreement(ctx, c.Environment.EnvironmentID, agreement.GetId()).Agreement(disabled).Execute()
+			},
+			fmt.Sprintf("[%s]-DISABLE", configKey),
+			sdk.DefaultCreateReadRetryable,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, language := range languages {
+		language := language
+
+		for _, revision := range language.revisions {
+			revision := revision
+
+			if currentRevision, ok := language.language.GetCurrentRevisionOk(); ok && currentRevision.GetId() == revision.GetId() {
+				continue
+			}
+
+			err := sdk.ParseResponse(
+				ctx,
+				func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.ManagementAPIClient.AgreementRevisionsResourcesApi.DeleteAgreementLanguageRevision(ctx, c.Environment.EnvironmentID, agreement.GetId(), language.language.GetId(), revision.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				fmt.Sprintf("[%s]-DELETEREVISION", configKey),
+				sdk.DefaultCreateReadRetryable,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := sdk.ParseResponse(
+			ctx,
+			func() (any, *http.Response, error) {
+				fR, fErr := c.Environment.Client.ManagementAPIClient.AgreementLanguagesResourcesApi.DeleteAgreementLanguage(ctx, c.Environment.EnvironmentID, agreement.GetId(), language.language.GetId()).Execute()
+				return nil, fR, fErr
+			},
+			fmt.Sprintf("[%s]-DELETELANGUAGE", configKey),
+			sdk.DefaultCreateReadRetryable,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *CleanEnvironmentAgreementsConfig) readAgreementLanguages(ctx context.Context, configKey, agreementID string) ([]agreementLanguageRevisions, error) {
+	var response *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.AgreementLanguagesResourcesApi.ReadAllAgreementLanguages(ctx, c.Environment.EnvironmentID, agreementID).Execute()
+		},
+		fmt.Sprintf("[%s]-READLANGUAGES", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make([]agreementLanguageRevisions, 0)
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok {
+		return languages, nil
+	}
+
+	for _, languageInner := range embedded.GetLanguages() {
+		if languageInner.AgreementLanguage == nil {
+			continue
+		}
+
+		language := *languageInner.AgreementLanguage
+
+		var revisionsResponse *management.EntityArray
+		err := sdk.ParseResponse(
+			ctx,
+			func() (any, *http.Response, error) {
+				return c.Environment.Client.ManagementAPIClient.AgreementRevisionsResourcesApi.ReadAllAgreementLanguageRevisions(ctx, c.Environment.EnvironmentID, agreementID, language.GetId()).Execute()
+			},
+			fmt.Sprintf("[%s]-READREVISIONS", configKey),
+			sdk.DefaultCreateReadRetryable,
+			&revisionsResponse,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions := make([]management.AgreementLanguageRevision, 0)
+		if revisionsEmbedded, ok := revisionsResponse.GetEmbeddedOk(); ok {
+			revisions = revisionsEmbedded.GetRevisions()
+		}
+
+		languages = append(languages, agreementLanguageRevisions{
+			language:  language,
+			revisions: revisions,
+		})
+	}
+
+	return languages, nil
+}
+
+// readSignOnPolicyAgreementReferences returns the names of the sign-on policies whose agreement actions refer to each agreement, keyed by agreement ID
+func (c *CleanEnvironmentAgreementsConfig) readSignOnPolicyAgreementReferences(ctx context.Context, configKey string) (map[string][]string, error) {
+	references := make(map[string][]string)
+
+	err := forEachSignOnPolicyAction(ctx, c.Environment, configKey, func(policy management.SignOnPolicy, action management.SignOnPolicyAction) {
+		if v := action.SignOnPolicyActionAgreement; v != nil {
+			agreementID := v.Agreement.GetId()
+
+			if !clean.Contains(references[agreementID], policy.GetName()) {
+				references[agreementID] = append(references[agreementID], policy.GetName())
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return references, nil
+}
@@ -0,0 +1,270 @@
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+var (
+	BootstrapApplicationNames = []string{
+		"Getting Started Application",
+	}
+)
+
+type CleanEnvironmentApplicationsConfig struct {
+	Environment                  clean.CleanEnvironmentConfig
+	BootstrapApplicationNames    []string
+	BootstrapApplicationPatterns []*regexp.Regexp
+	ApplicationTypes             []management.EnumApplicationType
+	Disable                      bool
+	Rules                        []clean.Rule
+	// WorkerClientID is the worker application running the sweep, which is never modified
+	WorkerClientID string
+}
+
+func (c *CleanEnvironmentApplicationsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Applications"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapApplicationNames) == 0 && len(c.BootstrapApplicationPatterns) == 0 && len(c.ApplicationTypes) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names, patterns, types or rules configured - skipping", configKey)
+		return nil
+	}
+
+	ok, err := clean.BillOfMaterialsHasService(ctx, configKey, c.Environment, management.ENUMPRODUCTTYPE_ONE_BASE)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		l.Info().Msgf("[%s] Bill of materials does not contain applicable service %s - skipping", configKey, management.ENUMPRODUCTTYPE_ONE_BASE)
+		return nil
+	}
+
+	var response *management.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.ApplicationsApi.ReadAllApplications(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasApplications() {
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, application := range embedded.GetApplications() {
+			application := application
+
+			common, err := applicationCommon(application)
+			if err != nil {
+				return err
+			}
+
+			if clean.IsSystemApplication(common.GetType()) {
+				l.Debug().Msgf(`[%s] Skipping PingOne system application "%s" (%s)`, configKey, common.GetName(), common.GetType())
+				continue
+			}
+
+			if common.GetId() == c.WorkerClientID {
+				l.Debug().Msgf(`[%s] Skipping the worker application "%s" used to run the sweep`, configKey, common.GetName())
+				continue
+			}
+
+			var deleteFunc, disableFunc sdk.SDKInterfaceFunc
+			var enabled *bool
+			var impact func() (*string, error)
+			if c.Disable {
+				enabled = &common.Enabled
+
+				// Deleting an application revokes its grants and secret, but disabling it does not, so they are revoked first.  This is done once,
+				// and not again if the update is retried.
+				revoked := false
+				disableFunc = func() (any, *http.Response, error) {
+					if !revoked {
+						if err := c.revokeApplicationAccess(ctx, configKey, application, common); err != nil {
+							return nil, nil, err
+						}
+						revoked = true
+					}
+
+					return c.disableApplication(ctx, application, common.GetId())
+				}
+
+				impact = func() (*string, error) {
+					impact := "its resource grants, which are revoked"
+					if hasClientSecret(application) {
+						impact = "its resource grants and client secret, which are revoked and rotated"
+					}
+					return &impact, nil
+				}
+			} else {
+				deleteFunc = func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.ManagementAPIClient.ApplicationsApi.DeleteApplication(ctx, c.Environment.EnvironmentID, common.GetId()).Execute()
+					return nil, fR, fErr
+				}
+			}
+
+			err = clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: common.GetName(),
+					Id:                   common.GetId(),
+					Description:          common.Description,
+					Object:               application,
+					Enabled:              enabled,
+					CreatedAt:            common.CreatedAt,
+					Impact:               impact,
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapApplicationNames,
+					PatternListToSearch:    c.BootstrapApplicationPatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+					Selectors: []clean.ConfigItemSelector{
+						{
+							Name:    fmt.Sprintf("type %s", common.GetType()),
+							Matched: clean.Contains(c.ApplicationTypes, common.GetType()),
+						},
+					},
+				},
+				deleteFunc,
+				disableFunc,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+// revokeApplicationAccess removes the resource grants of an application and, for OIDC applications with a client secret, rotates the secret so the
+// existing one can no longer be used while the application is disabled
+func (c *CleanEnvironmentApplicationsConfig) revokeApplicationAccess(ctx context.Context, configKey string, application management.ReadOneApplication200Response, common *management.Application) error {
+	l := logger.Get()
+
+	var grantsResponse *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.ApplicationResourceGrantsApi.ReadAllApplicationGrants(ctx, c.Environment.EnvironmentID, common.GetId()).Execute()
+		},
+		fmt.Sprintf("[%s]-READGRANTS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&grantsResponse,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := grantsResponse.GetEmbeddedOk(); ok {
+		for _, grant := range embedded.GetGrants() {
+			grant := grant
+
+			err := sdk.ParseResponse(
+				ctx,
+				func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.ManagementAPIClient.ApplicationResourceGrantsApi.DeleteApplicationGrant(ctx, c.Environment.EnvironmentID, common.GetId(), grant.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				fmt.Sprintf("[%s]-REVOKEGRANT", configKey),
+				sdk.DefaultCreateReadRetryable,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+
+			l.Debug().Msgf(`[%s] Revoked grant "%s" of application "%s"`, configKey, grant.GetId(), common.GetName())
+		}
+	}
+
+	if hasClientSecret(application) {
+		err := sdk.ParseResponse(
+			ctx,
+			func() (any, *http.Response, error) {
+				fR, fErr := c.Environment.Client.ManagementAPIClient.ApplicationSecretApi.UpdateApplicationSecret(ctx, c.Environment.EnvironmentID, common.GetId()).Execute()
+				return nil, fR, fErr
+			},
+			fmt.Sprintf("[%s]-REVOKESECRET", configKey),
+			sdk.DefaultCreateReadRetryable,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+
+		l.Debug().Msgf(`[%s] Revoked the secret of application "%s"`, configKey, common.GetName())
+	}
+
+	return nil
+}
+
+// disableApplication disables the application, sending back only its writable settings
+func (c *CleanEnvironmentApplicationsConfig) disableApplication(ctx context.Context, application management.ReadOneApplication200Response, applicationID string) (any, *http.Response, error) {
+
+	applicationMap, err := clean.WritableSettings(application)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applicationMap["enabled"] = false
+
+	updateBytes, err := json.Marshal(applicationMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var update management.UpdateApplicationRequest
+	if err := json.Unmarshal(updateBytes, &update); err != nil {
+		return nil, nil, err
+	}
+
+	return c.Environment.Client.ManagementAPIClient.ApplicationsApi.UpdateApplication(ctx, c.Environment.EnvironmentID, applicationID).UpdateApplicationRequest(update).Execute()
+}
+
+// applicationCommon returns the properties shared by all application types
+func applicationCommon(application management.ReadOneApplication200Response) (*management.Application, error) {
+	applicationBytes, err := json.Marshal(application)
+	if err != nil {
+		return nil, err
+	}
+
+	var common management.Application
+	if err := json.Unmarshal(applicationBytes, &common); err != nil {
+		return nil, err
+	}
+
+	return &common, nil
+}
+
+func hasClientSecret(application management.ReadOneApplication200Response) bool {
+	oidc := application.ApplicationOIDC
+	return oidc != nil && oidc.GetTokenEndpointAuthMethod() != management.ENUMAPPLICATIONOIDCTOKENAUTHMETHOD_NONE
+}
@@ -19,6 +19,7 @@ var (
 type CleanEnvironmentAuthenticationPoliciesConfig struct {
 	Environment                        clean.CleanEnvironmentConfig
 	BootstrapAuthenticationPolicyNames []string
+	Rules                              []clean.Rule
 }
 
 func (c *CleanEnvironmentAuthenticationPoliciesConfig) Clean(ctx context.Context) error {
@@ -28,8 +29,8 @@ func (c *CleanEnvironmentAuthenticationPoliciesConfig) Clean(ctx context.Context
 
 	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
 
-	if len(c.BootstrapAuthenticationPolicyNames) == 0 {
-		l.Info().Msgf("[%s] No bootstrap names configured - skipping", configKey)
+	if len(c.BootstrapAuthenticationPolicyNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
 		return nil
 	}
 
@@ -61,18 +62,28 @@ func (c *CleanEnvironmentAuthenticationPoliciesConfig) Clean(ctx context.Context
 
 		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
 		for _, policy := range embedded.GetSignOnPolicies() {
+			policy := policy
 
-			err := clean.TryCleanConfig(
+			createdAt, err := clean.ParseTimestamp(policy.CreatedAt)
+			if err != nil {
+				return err
+			}
+
+			err = clean.TryCleanConfig(
 				ctx,
 				configKey,
 				c.Environment,
 				clean.ConfigItem{
 					IdentifierToEvaluate: policy.Name,
 					Id:                   *policy.Id,
+					Description:          policy.Description,
+					Object:               policy,
 					Default:              policy.Default,
+					CreatedAt:            createdAt,
 				},
 				clean.ConfigItemEval{
 					IdentifierListToSearch: c.BootstrapAuthenticationPolicyNames,
+					Rules:                  c.Rules,
 					StartsWithStringMatch:  false,
 				},
 				func() (any, *http.Response, error) {
@@ -0,0 +1,298 @@
+package sso
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentGroupsConfig struct {
+	Environment            clean.CleanEnvironmentConfig
+	BootstrapGroupNames    []string
+	BootstrapGroupPatterns []*regexp.Regexp
+	EmptyOlderThanDays     int
+	Rules                  []clean.Rule
+}
+
+func (c *CleanEnvironmentGroupsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Groups"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapGroupNames) == 0 && len(c.BootstrapGroupPatterns) == 0 && c.EmptyOlderThanDays <= 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names, patterns, empty group age or rules configured - skipping", configKey)
+		return nil
+	}
+
+	var rawResponse *http.Response
+	var response *management.EntityArray
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			fO, fR, fErr := c.Environment.Client.ManagementAPIClient.GroupsApi.ReadAllGroups(ctx, c.Environment.EnvironmentID).Execute()
+			rawResponse = fR
+			return fO, fR, fErr
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	createdAt, err := clean.CreationTimes(rawResponse, "groups")
+	if err != nil {
+		return fmt.Errorf("[%s] Cannot read the creation time of groups: %w", configKey, err)
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasGroups() {
+
+		// Each group's parent groups are read up front, so that the nested groups of every group are known
+		parentGroupIDs := make(map[string][]string)
+		childGroupIDs := make(map[string][]string)
+		groupNames := make(map[string]string)
+		for _, group := range embedded.GetGroups() {
+			groupNames[group.GetId()] = group.GetName()
+
+			parents, err := c.readParentGroupIDs(ctx, configKey, group.GetId())
+			if err != nil {
+				return err
+			}
+
+			parentGroupIDs[group.GetId()] = parents
+			for _, parent := range parents {
+				childGroupIDs[parent] = append(childGroupIDs[parent], group.GetId())
+			}
+		}
+
+		var emptyCutoff *time.Time
+		if c.EmptyOlderThanDays > 0 {
+			cutoff := time.Now().AddDate(0, 0, -c.EmptyOlderThanDays)
+			emptyCutoff = &cutoff
+		}
+
+		var roleNames map[string]string
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, group := range embedded.GetGroups() {
+			group := group
+
+			// A group is only selected as empty when it was created before the cutoff and is empty now, as the API doesn't record when a group
+			// was last changed
+			empty := false
+			if emptyCutoff != nil && createdAt[group.GetId()] != nil && createdAt[group.GetId()].Before(*emptyCutoff) && len(childGroupIDs[group.GetId()]) == 0 {
+				hasUsers, err := c.groupHasUsers(ctx, configKey, group.GetId())
+				if err != nil {
+					return err
+				}
+
+				empty = !hasUsers
+			}
+
+			if parents := parentGroupIDs[group.GetId()]; len(parents) > 0 {
+				l.Debug().Msgf(`[%s] Group "%s" is nested in %d groups`, configKey, group.GetName(), len(parents))
+			}
+
+			err := clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: group.GetName(),
+					Id:                   group.GetId(),
+					Description:          group.Description,
+					Object:               group,
+					CreatedAt:            createdAt[group.GetId()],
+					BlockedBy: func() (*string, error) {
+						if roleNames == nil {
+							var err error
+							roleNames, err = readRoleNames(ctx, c.Environment, configKey)
+							if err != nil {
+								return nil, err
+							}
+						}
+
+						return c.adminRoleAssignments(ctx, configKey, group.GetId(), roleNames)
+					},
+					Impact: func() (*string, error) {
+						children := childGroupIDs[group.GetId()]
+						if len(children) == 0 {
+							return nil, nil
+						}
+
+						names := make([]string, 0, len(children))
+						for _, childGroupID := range children {
+							names = append(names, groupNames[childGroupID])
+						}
+
+						impact := fmt.Sprintf(`nested groups "%s", which are removed from the group first and kept`, strings.Join(names, `", "`))
+						return &impact, nil
+					},
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapGroupNames,
+					PatternListToSearch:    c.BootstrapGroupPatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+					Selectors: []clean.ConfigItemSelector{
+						{
+							Name:    fmt.Sprintf("empty and created more than %d days ago", c.EmptyOlderThanDays),
+							Matched: empty,
+						},
+					},
+				},
+				func() (any, *http.Response, error) {
+					// Nested groups are released from the group first, so that they are kept
+					for _, childGroupID := range childGroupIDs[group.GetId()] {
+						childGroupID := childGroupID
+
+						err := sdk.ParseResponse(
+							ctx,
+							func() (any, *http.Response, error) {
+								fR, fErr := c.Environment.Client.ManagementAPIClient.GroupsApi.DeleteGroupNesting(ctx, c.Environment.EnvironmentID, childGroupID, group.GetId()).Execute()
+								return nil, fR, fErr
+							},
+							fmt.Sprintf("[%s]-DELETENESTING", configKey),
+							sdk.DefaultCreateReadRetryable,
+							nil,
+						)
+						if err != nil {
+							return nil, nil, err
+						}
+					}
+
+					fR, fErr := c.Environment.Client.ManagementAPIClient.GroupsApi.DeleteGroup(ctx, c.Environment.EnvironmentID, group.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+func (c *CleanEnvironmentGroupsConfig) readParentGroupIDs(ctx context.Context, configKey, groupID string) ([]string, error) {
+	var response *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.GroupsApi.ReadGroupNesting(ctx, c.Environment.EnvironmentID, groupID).Execute()
+		},
+		fmt.Sprintf("[%s]-READNESTING", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	parents := make([]string, 0)
+	if embedded, ok := response.GetEmbeddedOk(); ok {
+		for _, membership := range embedded.GetGroupMemberships() {
+			parents = append(parents, membership.GetId())
+		}
+	}
+
+	return parents, nil
+}
+
+func (c *CleanEnvironmentGroupsConfig) groupHasUsers(ctx context.Context, configKey, groupID string) (bool, error) {
+	var response *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.UsersApi.ReadAllUsers(ctx, c.Environment.EnvironmentID).Filter(fmt.Sprintf(`memberOfGroups[id eq "%s"]`, groupID)).Limit(1).Execute()
+		},
+		fmt.Sprintf("[%s]-READMEMBERS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	embedded, ok := response.GetEmbeddedOk()
+	return ok && len(embedded.GetUsers()) > 0, nil
+}
+
+// readRoleNames returns the names of the PingOne admin roles, keyed by role ID
+func readRoleNames(ctx context.Context, env clean.CleanEnvironmentConfig, configKey string) (map[string]string, error) {
+	var response *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return env.Client.ManagementAPIClient.RolesApi.ReadAllRoles(ctx).Execute()
+		},
+		fmt.Sprintf("[%s]-READROLES", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	roleNames := make(map[string]string)
+	if embedded, ok := response.GetEmbeddedOk(); ok {
+		for _, role := range embedded.GetRoles() {
+			roleNames[role.GetId()] = string(role.GetName())
+		}
+	}
+
+	return roleNames, nil
+}
+
+// adminRoleAssignments returns a description of the admin roles assigned to the group, or nil if there are none
+func (c *CleanEnvironmentGroupsConfig) adminRoleAssignments(ctx context.Context, configKey, groupID string, roleNames map[string]string) (*string, error) {
+	var response *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.GroupRoleAssignmentsApi.ReadGroupRoleAssignments(ctx, c.Environment.EnvironmentID, groupID).Execute()
+		},
+		fmt.Sprintf("[%s]-READROLEASSIGNMENTS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok || len(embedded.GetRoleAssignments()) == 0 {
+		return nil, nil
+	}
+
+	roles := make([]string, 0)
+	for _, roleAssignment := range embedded.GetRoleAssignments() {
+		roleName, ok := roleNames[roleAssignment.Role.GetId()]
+		if !ok {
+			roleName = roleAssignment.Role.GetId()
+		}
+
+		roles = append(roles, fmt.Sprintf("%s (%s %s)", roleName, roleAssignment.Scope.GetType(), roleAssignment.Scope.GetId()))
+	}
+
+	reason := fmt.Sprintf("the group has admin role assignments %s", strings.Join(roles, ", "))
+	return &reason, nil
+}
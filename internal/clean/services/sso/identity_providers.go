@@ -0,0 +1,248 @@
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentIdentityProvidersConfig struct {
+	Environment                       clean.CleanEnvironmentConfig
+	BootstrapIdentityProviderNames    []string
+	BootstrapIdentityProviderPatterns []*regexp.Regexp
+	IdentityProviderTypes             []management.EnumIdentityProviderExt
+	Disabled                          bool
+	Rules                             []clean.Rule
+}
+
+func (c *CleanEnvironmentIdentityProvidersConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Identity Providers"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapIdentityProviderNames) == 0 && len(c.BootstrapIdentityProviderPatterns) == 0 && len(c.IdentityProviderTypes) == 0 && !c.Disabled && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names, patterns, types, disabled state or rules configured - skipping", configKey)
+		return nil
+	}
+
+	ok, err := clean.BillOfMaterialsHasService(ctx, configKey, c.Environment, management.ENUMPRODUCTTYPE_ONE_BASE)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		l.Info().Msgf("[%s] Bill of materials does not contain applicable service %s - skipping", configKey, management.ENUMPRODUCTTYPE_ONE_BASE)
+		return nil
+	}
+
+	var response *management.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.IdentityProvidersApi.ReadAllIdentityProviders(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasIdentityProviders() {
+
+		var references map[string][]string
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, identityProvider := range embedded.GetIdentityProviders() {
+			identityProvider := identityProvider
+
+			common, err := identityProviderCommon(identityProvider)
+			if err != nil {
+				return err
+			}
+
+			createdAt, err := clean.ParseTimestamp(common.CreatedAt)
+			if err != nil {
+				return err
+			}
+
+			err = clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: common.GetName(),
+					Id:                   common.GetId(),
+					Description:          common.Description,
+					Object:               identityProvider,
+					CreatedAt:            createdAt,
+					BlockedBy: func() (*string, error) {
+						if references == nil {
+							var err error
+							references, err = c.readSignOnPolicyReferences(ctx, configKey)
+							if err != nil {
+								return nil, err
+							}
+						}
+
+						if policies, ok := references[common.GetId()]; ok {
+							reason := fmt.Sprintf(`the identity provider is used by sign-on policies "%s"`, strings.Join(policies, `", "`))
+							return &reason, nil
+						}
+
+						return nil, nil
+					},
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapIdentityProviderNames,
+					PatternListToSearch:    c.BootstrapIdentityProviderPatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+					Selectors: []clean.ConfigItemSelector{
+						{
+							Name:    fmt.Sprintf("type %s", common.GetType()),
+							Matched: clean.Contains(c.IdentityProviderTypes, common.GetType()),
+						},
+						{
+							Name:    "disabled",
+							Matched: c.Disabled && !common.GetEnabled(),
+						},
+					},
+				},
+				func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.ManagementAPIClient.IdentityProvidersApi.DeleteIdentityProvider(ctx, c.Environment.EnvironmentID, common.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+// readSignOnPolicyReferences returns the names of the sign-on policies whose login, identifier first or identity provider actions refer to each identity provider, keyed by identity provider ID
+func (c *CleanEnvironmentIdentityProvidersConfig) readSignOnPolicyReferences(ctx context.Context, configKey string) (map[string][]string, error) {
+	references := make(map[string][]string)
+
+	err := forEachSignOnPolicyAction(ctx, c.Environment, configKey, func(policy management.SignOnPolicy, action management.SignOnPolicyAction) {
+		identityProviderIDs := make([]string, 0)
+
+		if v := action.SignOnPolicyActionLogin; v != nil {
+			for _, socialProvider := range v.GetSocialProviders() {
+				identityProviderIDs = append(identityProviderIDs, socialProvider.GetId())
+			}
+		}
+
+		if v := action.SignOnPolicyActionIDFirst; v != nil {
+			for _, discoveryRule := range v.GetDiscoveryRules() {
+				identityProviderIDs = append(identityProviderIDs, discoveryRule.IdentityProvider.GetId())
+			}
+
+			for _, socialProvider := range v.GetSocialProviders() {
+				identityProviderIDs = append(identityProviderIDs, socialProvider.GetId())
+			}
+		}
+
+		if v := action.SignOnPolicyActionIDP; v != nil {
+			identityProviderIDs = append(identityProviderIDs, v.IdentityProvider.GetId())
+		}
+
+		for _, identityProviderID := range identityProviderIDs {
+			if !clean.Contains(references[identityProviderID], policy.GetName()) {
+				references[identityProviderID] = append(references[identityProviderID], policy.GetName())
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return references, nil
+}
+
+// forEachSignOnPolicyAction calls the function with each action of each sign-on policy in the environment
+func forEachSignOnPolicyAction(ctx context.Context, env clean.CleanEnvironmentConfig, configKey string, f func(management.SignOnPolicy, management.SignOnPolicyAction)) error {
+	var policiesResponse *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return env.Client.ManagementAPIClient.SignOnPoliciesApi.ReadAllSignOnPolicies(ctx, env.EnvironmentID).Execute()
+		},
+		fmt.Sprintf("[%s]-READSIGNONPOLICIES", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&policiesResponse,
+	)
+	if err != nil {
+		return err
+	}
+
+	embedded, ok := policiesResponse.GetEmbeddedOk()
+	if !ok {
+		return nil
+	}
+
+	for _, policy := range embedded.GetSignOnPolicies() {
+		policy := policy
+
+		var actionsResponse *management.EntityArray
+		err := sdk.ParseResponse(
+			ctx,
+			func() (any, *http.Response, error) {
+				return env.Client.ManagementAPIClient.SignOnPolicyActionsApi.ReadAllSignOnPolicyActions(ctx, env.EnvironmentID, policy.GetId()).Execute()
+			},
+			fmt.Sprintf("[%s]-READSIGNONPOLICYACTIONS", configKey),
+			sdk.DefaultCreateReadRetryable,
+			&actionsResponse,
+		)
+		if err != nil {
+			return err
+		}
+
+		actionsEmbedded, ok := actionsResponse.GetEmbeddedOk()
+		if !ok {
+			continue
+		}
+
+		for _, action := range actionsEmbedded.GetActions() {
+			f(policy, action)
+		}
+	}
+
+	return nil
+}
+
+// identityProviderCommon returns the properties shared by all identity provider types
+func identityProviderCommon(identityProvider management.IdentityProvider) (*management.IdentityProviderCommon, error) {
+	identityProviderBytes, err := json.Marshal(identityProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	var common management.IdentityProviderCommon
+	if err := json.Unmarshal(identityProviderBytes, &common); err != nil {
+		return nil, err
+	}
+
+	return &common, nil
+}
@@ -15,11 +15,15 @@ var (
 		"Basic",
 		"Passphrase",
 	}
+
+	BootstrapPasswordPolicyFingerprints = map[string]string{}
 )
 
 type CleanEnvironmentPlatformPasswordPoliciesConfig struct {
-	Environment                  clean.CleanEnvironmentConfig
-	BootstrapPasswordPolicyNames []string
+	Environment                         clean.CleanEnvironmentConfig
+	BootstrapPasswordPolicyNames        []string
+	BootstrapPasswordPolicyFingerprints map[string]string
+	Rules                               []clean.Rule
 }
 
 func (c *CleanEnvironmentPlatformPasswordPoliciesConfig) Clean(ctx context.Context) error {
@@ -29,8 +33,8 @@ func (c *CleanEnvironmentPlatformPasswordPoliciesConfig) Clean(ctx context.Conte
 
 	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
 
-	if len(c.BootstrapPasswordPolicyNames) == 0 {
-		l.Info().Msgf("[%s] No bootstrap names configured - skipping", configKey)
+	if len(c.BootstrapPasswordPolicyNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
 		return nil
 	}
 
@@ -62,19 +66,36 @@ func (c *CleanEnvironmentPlatformPasswordPoliciesConfig) Clean(ctx context.Conte
 
 		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
 		for _, policy := range embedded.GetPasswordPolicies() {
+			policy := policy
+
+			createdAt, err := clean.ParseTimestamp(policy.CreatedAt)
+			if err != nil {
+				return err
+			}
+
+			fingerprint, err := clean.Fingerprint(policy, "name", "description", "default", "populationCount")
+			if err != nil {
+				return err
+			}
 
-			err := clean.TryCleanConfig(
+			err = clean.TryCleanConfig(
 				ctx,
 				configKey,
 				c.Environment,
 				clean.ConfigItem{
 					IdentifierToEvaluate: policy.Name,
 					Id:                   *policy.Id,
+					Description:          policy.Description,
+					Object:               policy,
 					Default:              policy.Default,
+					Fingerprint:          &fingerprint,
+					CreatedAt:            createdAt,
 				},
 				clean.ConfigItemEval{
 					IdentifierListToSearch: c.BootstrapPasswordPolicyNames,
+					Rules:                  c.Rules,
 					StartsWithStringMatch:  false,
+					BootstrapFingerprints:  c.BootstrapPasswordPolicyFingerprints,
 				},
 				func() (any, *http.Response, error) {
 					fR, fErr := c.Environment.Client.ManagementAPIClient.PasswordPoliciesApi.DeletePasswordPolicy(ctx, c.Environment.EnvironmentID, policy.GetId()).Execute()
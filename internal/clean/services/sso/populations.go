@@ -0,0 +1,351 @@
+package sso
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+const (
+	DefaultUserConcurrency = 5
+
+	usersPageSize = 100
+)
+
+var (
+	BootstrapPopulationNames = []string{
+		"Sample Users",
+	}
+
+	// errStopUserPaging is returned by a forEachUserPage function to stop reading further pages
+	errStopUserPaging = errors.New("stop reading users")
+)
+
+type CleanEnvironmentPopulationsConfig struct {
+	Environment              clean.CleanEnvironmentConfig
+	BootstrapPopulationNames []string
+	TargetPopulationName     string
+	UserConcurrency          int
+	Rules                    []clean.Rule
+	// DeleteUsers allows populations that still have users to be deleted with their users, when the users aren't moved to the target population
+	DeleteUsers bool
+}
+
+// populationUsers summarises the users of a population, without holding the users themselves
+type populationUsers struct {
+	Count     int
+	Protected []string
+}
+
+func (c *CleanEnvironmentPopulationsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Populations"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapPopulationNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
+		return nil
+	}
+
+	ok, err := clean.BillOfMaterialsHasService(ctx, configKey, c.Environment, management.ENUMPRODUCTTYPE_ONE_BASE)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		l.Info().Msgf("[%s] Bill of materials does not contain applicable service %s - skipping", configKey, management.ENUMPRODUCTTYPE_ONE_BASE)
+		return nil
+	}
+
+	var response *management.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.PopulationsApi.ReadAllPopulations(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasPopulations() {
+
+		var targetPopulationID *string
+		if c.TargetPopulationName != "" {
+			for _, population := range embedded.GetPopulations() {
+				if population.GetName() == c.TargetPopulationName {
+					targetPopulationID = population.Id
+					break
+				}
+			}
+
+			if targetPopulationID == nil {
+				return fmt.Errorf(`[%s] Target population "%s" cannot be found in the target environment`, configKey, c.TargetPopulationName)
+			}
+		}
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, population := range embedded.GetPopulations() {
+			population := population
+
+			if targetPopulationID != nil && population.GetId() == *targetPopulationID {
+				l.Debug().Msgf(`[%s] Skipping target population "%s"`, configKey, population.GetName())
+				continue
+			}
+
+			createdAt, err := clean.ParseTimestamp(population.CreatedAt)
+			if err != nil {
+				return err
+			}
+
+			// The users are read at most once per population, for either the blocked check or the preview
+			var users *populationUsers
+			readUsers := func() (*populationUsers, error) {
+				if users == nil {
+					var err error
+					users, err = c.readPopulationUsers(ctx, configKey, population.GetId())
+					if err != nil {
+						return nil, err
+					}
+				}
+				return users, nil
+			}
+
+			err = clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: population.GetName(),
+					Id:                   population.GetId(),
+					Description:          population.Description,
+					Object:               population,
+					Default:              population.Default,
+					CreatedAt:            createdAt,
+					BlockedBy: func() (*string, error) {
+						users, err := readUsers()
+						if err != nil {
+							return nil, err
+						}
+
+						// Moved users are kept, so only deleted users need to be allowed and checked for protection
+						if users.Count == 0 || targetPopulationID != nil {
+							return nil, nil
+						}
+
+						if !c.DeleteUsers {
+							reason := fmt.Sprintf("it has %d users, and deleting the users of a population is not switched on", users.Count)
+							return &reason, nil
+						}
+
+						if len(users.Protected) > 0 {
+							reason := fmt.Sprintf(`it has protected users "%s"`, strings.Join(users.Protected, `", "`))
+							return &reason, nil
+						}
+
+						return nil, nil
+					},
+					Impact: func() (*string, error) {
+						users, err := readUsers()
+						if err != nil {
+							return nil, err
+						}
+
+						if users.Count == 0 {
+							return nil, nil
+						}
+
+						impact := fmt.Sprintf("%d users, which are deleted first", users.Count)
+						if targetPopulationID != nil {
+							impact = fmt.Sprintf(`%d users, which are moved to population "%s" first`, users.Count, c.TargetPopulationName)
+						}
+						return &impact, nil
+					},
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapPopulationNames,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+				},
+				func() (any, *http.Response, error) {
+					if err := c.emptyPopulation(ctx, configKey, population, targetPopulationID); err != nil {
+						return nil, nil, err
+					}
+
+					fR, fErr := c.Environment.Client.ManagementAPIClient.PopulationsApi.DeletePopulation(ctx, c.Environment.EnvironmentID, population.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+// readPopulationUsers counts the users of a population and lists those that are protected
+func (c *CleanEnvironmentPopulationsConfig) readPopulationUsers(ctx context.Context, configKey, populationID string) (*populationUsers, error) {
+	users := &populationUsers{
+		Protected: make([]string, 0),
+	}
+
+	err := forEachUserPage(ctx, c.Environment, configKey, fmt.Sprintf(`population.id eq "%s"`, populationID), func(page []management.User) error {
+		for _, user := range page {
+			users.Count++
+
+			if c.Environment.Protection.IsProtected(clean.ConfigItem{IdentifierToEvaluate: user.GetUsername(), Id: user.GetId()}) {
+				users.Protected = append(users.Protected, user.GetUsername())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// emptyPopulation removes the users of a population, either by deleting them or moving them to the target population
+func (c *CleanEnvironmentPopulationsConfig) emptyPopulation(ctx context.Context, configKey string, population management.Population, targetPopulationID *string) error {
+	l := logger.Get()
+
+	// The user IDs are collected before any are changed, so that removing users doesn't disturb the paging of the results
+	userIDs := make([]string, 0, population.GetUserCount())
+	err := forEachUserPage(ctx, c.Environment, configKey, fmt.Sprintf(`population.id eq "%s"`, population.GetId()), func(users []management.User) error {
+		for _, user := range users {
+			userIDs = append(userIDs, user.GetId())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	if targetPopulationID != nil {
+		l.Info().Msgf(`[%s] Moving %d users from population "%s" to population "%s"`, configKey, len(userIDs), population.GetName(), c.TargetPopulationName)
+	} else {
+		l.Info().Msgf(`[%s] Deleting %d users from population "%s"`, configKey, len(userIDs), population.GetName())
+	}
+
+	return clean.ForEachConcurrently(ctx, c.UserConcurrency, userIDs, func(ctx context.Context, userID string) error {
+		if targetPopulationID != nil {
+			return sdk.ParseResponse(
+				ctx,
+				func() (any, *http.Response, error) {
+					return c.Environment.Client.ManagementAPIClient.UserPopulationsApi.UpdateUserPopulation(ctx, c.Environment.EnvironmentID, userID).UserPopulation(*management.NewUserPopulation(*targetPopulationID)).Execute()
+				},
+				fmt.Sprintf("[%s]-MOVEUSER", configKey),
+				sdk.DefaultCreateReadRetryable,
+				nil,
+			)
+		}
+
+		return sdk.ParseResponse(
+			ctx,
+			func() (any, *http.Response, error) {
+				fR, fErr := c.Environment.Client.ManagementAPIClient.UsersApi.DeleteUser(ctx, c.Environment.EnvironmentID, userID).Execute()
+				return nil, fR, fErr
+			},
+			fmt.Sprintf("[%s]-DELETEUSER", configKey),
+			sdk.DefaultCreateReadRetryable,
+			nil,
+		)
+	})
+}
+
+// forEachUserPage reads the users that match the SCIM filter one page at a time, following the cursor of each page to the next.  The cursor to the
+// next page is taken before the function is called, so that users the function removes don't move the following pages.
+func forEachUserPage(ctx context.Context, env clean.CleanEnvironmentConfig, configKey, filter string, f func([]management.User) error) error {
+	var cursor *string
+
+	for {
+		var response *management.EntityArray
+		err := sdk.ParseResponse(
+			ctx,
+			func() (any, *http.Response, error) {
+				request := env.Client.ManagementAPIClient.UsersApi.ReadAllUsers(ctx, env.EnvironmentID).Limit(usersPageSize)
+				if filter != "" {
+					request = request.Filter(filter)
+				}
+				if cursor != nil {
+					request = request.Cursor(*cursor)
+				}
+				return request.Execute()
+			},
+			fmt.Sprintf("[%s]-READUSERS", configKey),
+			sdk.DefaultCreateReadRetryable,
+			&response,
+		)
+		if err != nil {
+			return err
+		}
+
+		if response == nil {
+			return nil
+		}
+
+		cursor, err = nextPageCursor(response)
+		if err != nil {
+			return fmt.Errorf("[%s] Cannot read the next page of users: %w", configKey, err)
+		}
+
+		if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasUsers() {
+			if err := f(embedded.GetUsers()); err != nil {
+				if errors.Is(err, errStopUserPaging) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if cursor == nil {
+			return nil
+		}
+	}
+}
+
+func nextPageCursor(response *management.EntityArray) (*string, error) {
+	links, ok := response.GetLinksOk()
+	if !ok || links.Next == nil || links.Next.Href == nil {
+		return nil, nil
+	}
+
+	nextURL, err := url.Parse(*links.Next.Href)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := nextURL.Query().Get("cursor")
+	if cursor == "" {
+		return nil, nil
+	}
+
+	return &cursor, nil
+}
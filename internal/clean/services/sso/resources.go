@@ -0,0 +1,301 @@
+package sso
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+var (
+	// The built-in resources are never modified, regardless of the selection criteria
+	systemResourceNames = []string{
+		"openid",
+		"PingOne API",
+	}
+)
+
+type CleanEnvironmentResourcesConfig struct {
+	Environment                    clean.CleanEnvironmentConfig
+	BootstrapResourceNames         []string
+	BootstrapResourcePatterns      []*regexp.Regexp
+	BootstrapResourceScopeNames    []string
+	BootstrapResourceScopePatterns []*regexp.Regexp
+	Orphaned                       bool
+	Rules                          []clean.Rule
+}
+
+// resourceGrants describes the applications that have been granted each resource scope
+type resourceGrants struct {
+	applicationsByScopeID map[string][]string
+}
+
+func (c *CleanEnvironmentResourcesConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Resources"
+	scopeConfigKey := "Resource Scopes"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	cleanScopes := len(c.BootstrapResourceScopeNames) > 0 || len(c.BootstrapResourceScopePatterns) > 0
+
+	if len(c.BootstrapResourceNames) == 0 && len(c.BootstrapResourcePatterns) == 0 && !cleanScopes && !c.Orphaned && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names, patterns, scopes, orphan detection or rules configured - skipping", configKey)
+		return nil
+	}
+
+	ok, err := clean.BillOfMaterialsHasService(ctx, configKey, c.Environment, management.ENUMPRODUCTTYPE_ONE_BASE)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		l.Info().Msgf("[%s] Bill of materials does not contain applicable service %s - skipping", configKey, management.ENUMPRODUCTTYPE_ONE_BASE)
+		return nil
+	}
+
+	var response *management.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.ResourcesApi.ReadAllResources(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasResources() {
+
+		var grants *resourceGrants
+		readGrants := func() (*resourceGrants, error) {
+			if grants == nil {
+				var err error
+				grants, err = c.readResourceGrants(ctx, configKey)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return grants, nil
+		}
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, resource := range embedded.GetResources() {
+			resource := resource
+
+			if resource.GetType() != management.ENUMRESOURCETYPE_CUSTOM || clean.Contains(systemResourceNames, resource.GetName()) {
+				l.Debug().Msgf(`[%s] Skipping built-in resource "%s"`, configKey, resource.GetName())
+				continue
+			}
+
+			scopes, err := c.readResourceScopes(ctx, configKey, resource.GetId())
+			if err != nil {
+				return err
+			}
+
+			if cleanScopes {
+				for _, scope := range scopes {
+					scope := scope
+
+					err := clean.TryCleanConfig(
+						ctx,
+						scopeConfigKey,
+						c.Environment,
+						clean.ConfigItem{
+							IdentifierToEvaluate: scope.GetName(),
+							Id:                   scope.GetId(),
+							Description:          scope.Description,
+							Object:               scope,
+							CreatedAt:            scope.CreatedAt,
+							BlockedBy: func() (*string, error) {
+								grants, err := readGrants()
+								if err != nil {
+									return nil, err
+								}
+
+								if applications, ok := grants.applicationsByScopeID[scope.GetId()]; ok {
+									reason := fmt.Sprintf(`the scope is granted to applications "%s"`, strings.Join(applications, `", "`))
+									return &reason, nil
+								}
+
+								return nil, nil
+							},
+						},
+						clean.ConfigItemEval{
+							IdentifierListToSearch: c.BootstrapResourceScopeNames,
+							PatternListToSearch:    c.BootstrapResourceScopePatterns,
+							StartsWithStringMatch:  false,
+						},
+						func() (any, *http.Response, error) {
+							fR, fErr := c.Environment.Client.ManagementAPIClient.ResourceScopesApi.DeleteResourceScope(ctx, c.Environment.EnvironmentID, resource.GetId(), scope.GetId()).Execute()
+							return nil, fR, fErr
+						},
+						nil,
+					)
+
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			orphaned := false
+			if c.Orphaned {
+				grants, err := readGrants()
+				if err != nil {
+					return err
+				}
+
+				orphaned = grants.orphaned(scopes)
+				if orphaned {
+					l.Info().Msgf(`[%s] Resource "%s" is orphaned - none of its scopes are granted to an application`, configKey, resource.GetName())
+				}
+			}
+
+			err = clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: resource.GetName(),
+					Id:                   resource.GetId(),
+					Description:          resource.Description,
+					Object:               resource,
+					CreatedAt:            resource.CreatedAt,
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapResourceNames,
+					PatternListToSearch:    c.BootstrapResourcePatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+					Selectors: []clean.ConfigItemSelector{
+						{
+							Name:    "orphaned",
+							Matched: orphaned,
+						},
+					},
+				},
+				func() (any, *http.Response, error) {
+					fR, fErr := c.Environment.Client.ManagementAPIClient.ResourcesApi.DeleteResource(ctx, c.Environment.EnvironmentID, resource.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+func (c *CleanEnvironmentResourcesConfig) readResourceScopes(ctx context.Context, configKey, resourceID string) ([]management.ResourceScope, error) {
+	var response *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.ResourceScopesApi.ReadAllResourceScopes(ctx, c.Environment.EnvironmentID, resourceID).Execute()
+		},
+		fmt.Sprintf("[%s]-READSCOPES", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok {
+		return embedded.GetScopes(), nil
+	}
+
+	return []management.ResourceScope{}, nil
+}
+
+// readResourceGrants reads the resource grants of every application in the environment
+func (c *CleanEnvironmentResourcesConfig) readResourceGrants(ctx context.Context, configKey string) (*resourceGrants, error) {
+	grants := &resourceGrants{
+		applicationsByScopeID: make(map[string][]string),
+	}
+
+	var applicationsResponse *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.ApplicationsApi.ReadAllApplications(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		fmt.Sprintf("[%s]-READAPPLICATIONS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&applicationsResponse,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	embedded, ok := applicationsResponse.GetEmbeddedOk()
+	if !ok {
+		return grants, nil
+	}
+
+	for _, application := range embedded.GetApplications() {
+		common, err := applicationCommon(application)
+		if err != nil {
+			return nil, err
+		}
+
+		var grantsResponse *management.EntityArray
+		err = sdk.ParseResponse(
+			ctx,
+			func() (any, *http.Response, error) {
+				return c.Environment.Client.ManagementAPIClient.ApplicationResourceGrantsApi.ReadAllApplicationGrants(ctx, c.Environment.EnvironmentID, common.GetId()).Execute()
+			},
+			fmt.Sprintf("[%s]-READGRANTS", configKey),
+			sdk.DefaultCreateReadRetryable,
+			&grantsResponse,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		grantsEmbedded, ok := grantsResponse.GetEmbeddedOk()
+		if !ok {
+			continue
+		}
+
+		for _, grant := range grantsEmbedded.GetGrants() {
+			for _, scope := range grant.GetScopes() {
+				if !clean.Contains(grants.applicationsByScopeID[scope.GetId()], common.GetName()) {
+					grants.applicationsByScopeID[scope.GetId()] = append(grants.applicationsByScopeID[scope.GetId()], common.GetName())
+				}
+			}
+		}
+	}
+
+	return grants, nil
+}
+
+// orphaned returns true if none of the scopes are granted to an application
+func (g *resourceGrants) orphaned(scopes []management.ResourceScope) bool {
+	for _, scope := range scopes {
+		if _, ok := g.applicationsByScopeID[scope.GetId()]; ok {
+			return false
+		}
+	}
+	return true
+}
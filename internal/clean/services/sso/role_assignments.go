@@ -0,0 +1,281 @@
+package sso
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentRoleAssignmentsConfig struct {
+	Environment clean.CleanEnvironmentConfig
+	// WorkerClientID is the worker application running the sweep, whose role assignments are never listed or revoked
+	WorkerClientID string
+	Revoke         bool
+	Rules          []clean.Rule
+}
+
+type roleAssignmentPrincipalType string
+
+const (
+	roleAssignmentPrincipalTypeUser        roleAssignmentPrincipalType = "user"
+	roleAssignmentPrincipalTypeGroup       roleAssignmentPrincipalType = "group"
+	roleAssignmentPrincipalTypeApplication roleAssignmentPrincipalType = "application"
+)
+
+// roleAssignmentPrincipal is the user, group or application that admin roles are assigned to
+type roleAssignmentPrincipal struct {
+	Type    roleAssignmentPrincipalType `json:"type"`
+	Id      string                      `json:"id"`
+	Name    string                      `json:"name"`
+	Enabled bool                        `json:"enabled"`
+}
+
+// roleAssignmentItem is the object that rules are evaluated against, a role assignment with its role name and principal
+type roleAssignmentItem struct {
+	Id        string                         `json:"id"`
+	RoleName  string                         `json:"roleName"`
+	Role      management.RoleAssignmentRole  `json:"role"`
+	Scope     management.RoleAssignmentScope `json:"scope"`
+	ReadOnly  bool                           `json:"readOnly"`
+	Principal roleAssignmentPrincipal        `json:"principal"`
+}
+
+func (c *CleanEnvironmentRoleAssignmentsConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Role Assignments"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	// Without the worker's ID, the sweep cannot be sure it isn't revoking its own access
+	if c.Revoke && c.WorkerClientID == "" {
+		return fmt.Errorf("[%s] The worker client ID is not set, role assignments cannot be revoked without it", configKey)
+	}
+
+	roleNames, err := readRoleNames(ctx, c.Environment, configKey)
+	if err != nil {
+		return err
+	}
+
+	found := false
+
+	l.Debug().Msgf("[%s] Reading users..", configKey)
+	err = forEachUserPage(ctx, c.Environment, configKey, "", func(users []management.User) error {
+		for _, user := range users {
+			enabled, ok := user.GetEnabledOk()
+
+			err := c.cleanPrincipalRoleAssignments(ctx, configKey, roleNames, roleAssignmentPrincipal{
+				Type:    roleAssignmentPrincipalTypeUser,
+				Id:      user.GetId(),
+				Name:    user.GetUsername(),
+				Enabled: !ok || *enabled,
+			}, &found)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var groupsResponse *management.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.GroupsApi.ReadAllGroups(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&groupsResponse,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := groupsResponse.GetEmbeddedOk(); ok {
+		for _, group := range embedded.GetGroups() {
+			err := c.cleanPrincipalRoleAssignments(ctx, configKey, roleNames, roleAssignmentPrincipal{
+				Type:    roleAssignmentPrincipalTypeGroup,
+				Id:      group.GetId(),
+				Name:    group.GetName(),
+				Enabled: true,
+			}, &found)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	var applicationsResponse *management.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.ApplicationsApi.ReadAllApplications(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&applicationsResponse,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := applicationsResponse.GetEmbeddedOk(); ok {
+		for _, application := range embedded.GetApplications() {
+			common, err := applicationCommon(application)
+			if err != nil {
+				return fmt.Errorf("[%s] Cannot read the application: %w", configKey, err)
+			}
+
+			// Only worker applications can be assigned admin roles
+			if common.GetType() != management.ENUMAPPLICATIONTYPE_WORKER {
+				continue
+			}
+
+			if common.GetId() == c.WorkerClientID {
+				l.Debug().Msgf(`[%s] Skipping the worker application "%s" used to run the sweep`, configKey, common.GetName())
+				continue
+			}
+
+			err = c.cleanPrincipalRoleAssignments(ctx, configKey, roleNames, roleAssignmentPrincipal{
+				Type:    roleAssignmentPrincipalTypeApplication,
+				Id:      common.GetId(),
+				Name:    common.GetName(),
+				Enabled: common.GetEnabled(),
+			}, &found)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if found {
+		l.Debug().Msgf("[%s] Done", configKey)
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+func (c *CleanEnvironmentRoleAssignmentsConfig) cleanPrincipalRoleAssignments(ctx context.Context, configKey string, roleNames map[string]string, principal roleAssignmentPrincipal, found *bool) error {
+	var response *management.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			switch principal.Type {
+			case roleAssignmentPrincipalTypeUser:
+				return c.Environment.Client.ManagementAPIClient.UserRoleAssignmentsApi.ReadUserRoleAssignments(ctx, c.Environment.EnvironmentID, principal.Id).Execute()
+			case roleAssignmentPrincipalTypeGroup:
+				return c.Environment.Client.ManagementAPIClient.GroupRoleAssignmentsApi.ReadGroupRoleAssignments(ctx, c.Environment.EnvironmentID, principal.Id).Execute()
+			default:
+				return c.Environment.Client.ManagementAPIClient.ApplicationRoleAssignmentsApi.ReadApplicationRoleAssignments(ctx, c.Environment.EnvironmentID, principal.Id).Execute()
+			}
+		},
+		fmt.Sprintf("[%s]-READROLEASSIGNMENTS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok {
+		return nil
+	}
+
+	for _, roleAssignment := range embedded.GetRoleAssignments() {
+		roleAssignment := roleAssignment
+
+		*found = true
+
+		roleName, ok := roleNames[roleAssignment.Role.GetId()]
+		if !ok {
+			roleName = roleAssignment.Role.GetId()
+		}
+
+		configItem := clean.ConfigItem{
+			IdentifierToEvaluate: fmt.Sprintf(`%s (%s %s) assigned to %s "%s"`, roleName, roleAssignment.Scope.GetType(), roleAssignment.Scope.GetId(), principal.Type, principal.Name),
+			Id:                   roleAssignment.GetId(),
+			Object: roleAssignmentItem{
+				Id:        roleAssignment.GetId(),
+				RoleName:  roleName,
+				Role:      roleAssignment.Role,
+				Scope:     roleAssignment.Scope,
+				ReadOnly:  roleAssignment.GetReadOnly(),
+				Principal: principal,
+			},
+			BlockedBy: func() (*string, error) {
+				if roleAssignment.GetReadOnly() {
+					reason := "the role assignment is read only"
+					return &reason, nil
+				}
+
+				return nil, nil
+			},
+		}
+
+		configItemEval := clean.ConfigItemEval{
+			Rules: c.Rules,
+			Selectors: []clean.ConfigItemSelector{
+				{
+					Name:    "organization scope",
+					Matched: roleAssignment.Scope.GetType() == management.ENUMROLEASSIGNMENTSCOPETYPE_ORGANIZATION,
+				},
+				{
+					Name:    "Environment Admin on an application",
+					Matched: principal.Type == roleAssignmentPrincipalTypeApplication && roleName == string(management.ENUMROLENAME_ENVIRONMENT_ADMIN),
+				},
+				{
+					Name:    "disabled user",
+					Matched: principal.Type == roleAssignmentPrincipalTypeUser && !principal.Enabled,
+				},
+			},
+		}
+
+		if !c.Revoke {
+			err := clean.ReportConfig(configKey, c.Environment, configItem, configItemEval, clean.ENUMCLEANOUTPUTACTION_DELETE, "revoking role assignments is not switched on")
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err := clean.TryCleanConfig(
+			ctx,
+			configKey,
+			c.Environment,
+			configItem,
+			configItemEval,
+			func() (any, *http.Response, error) {
+				var fR *http.Response
+				var fErr error
+				switch principal.Type {
+				case roleAssignmentPrincipalTypeUser:
+					fR, fErr = c.Environment.Client.ManagementAPIClient.UserRoleAssignmentsApi.DeleteUserRoleAssignment(ctx, c.Environment.EnvironmentID, principal.Id, roleAssignment.GetId()).Execute()
+				case roleAssignmentPrincipalTypeGroup:
+					fR, fErr = c.Environment.Client.ManagementAPIClient.GroupRoleAssignmentsApi.DeleteGroupRoleAssignment(ctx, c.Environment.EnvironmentID, principal.Id, roleAssignment.GetId()).Execute()
+				default:
+					fR, fErr = c.Environment.Client.ManagementAPIClient.ApplicationRoleAssignmentsApi.DeleteApplicationRoleAssignment(ctx, c.Environment.EnvironmentID, principal.Id, roleAssignment.GetId()).Execute()
+				}
+				return nil, fR, fErr
+			},
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
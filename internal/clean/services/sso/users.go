@@ -0,0 +1,197 @@
+package sso
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentUsersConfig struct {
+	Environment             clean.CleanEnvironmentConfig
+	Filter                  string
+	PopulationNames         []string
+	LastSignOnOlderThanDays int
+	MaxDeletes              int
+	Disable                 bool
+	Rules                   []clean.Rule
+}
+
+func (c *CleanEnvironmentUsersConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Users"
+
+	l.Debug().Msgf(`[%s] Cleaning config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	// Unlike bootstrap configuration, there are no default users to select, so nothing is selected unless criteria are configured
+	if c.Filter == "" && len(c.PopulationNames) == 0 && c.LastSignOnOlderThanDays <= 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No filter, populations, last sign-on age or rules configured - skipping", configKey)
+		return nil
+	}
+
+	filter, err := c.scimFilter(ctx, configKey)
+	if err != nil {
+		return err
+	}
+
+	l.Debug().Msgf(`[%s] Reading users with filter "%s"..`, configKey, filter)
+
+	selector := clean.ConfigItemSelector{
+		Name:    c.selectorName(filter),
+		Matched: filter != "" || c.LastSignOnOlderThanDays > 0,
+	}
+
+	limit := &clean.ActionLimit{
+		Max: c.MaxDeletes,
+	}
+
+	var lastSignOnCutoff *time.Time
+	if c.LastSignOnOlderThanDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -c.LastSignOnOlderThanDays)
+		lastSignOnCutoff = &cutoff
+	}
+
+	// Users are acted on a page at a time, so that large result sets are never held in memory, and paging stops once the limit is reached
+	err = forEachUserPage(ctx, c.Environment, configKey, filter, func(users []management.User) error {
+		for _, user := range users {
+			if limit.Reached() {
+				l.Warn().Msgf("[%s] The maximum of %d users has been reached - the remaining users have not been evaluated", configKey, c.MaxDeletes)
+				return errStopUserPaging
+			}
+
+			if lastSignOnCutoff != nil && !signedOnBefore(user, *lastSignOnCutoff) {
+				continue
+			}
+
+			if err := c.cleanUser(ctx, configKey, user, selector, limit); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	l.Debug().Msgf("[%s] Done", configKey)
+
+	return nil
+}
+
+func (c *CleanEnvironmentUsersConfig) cleanUser(ctx context.Context, configKey string, user management.User, selector clean.ConfigItemSelector, limit *clean.ActionLimit) error {
+	var deleteFunc, disableFunc sdk.SDKInterfaceFunc
+	var enabled *bool
+	if c.Disable {
+		enabled = user.Enabled
+		disableFunc = func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.EnableUsersApi.UpdateUserEnabled(ctx, c.Environment.EnvironmentID, user.GetId()).UserEnabled(management.UserEnabled{Enabled: management.PtrBool(false)}).Execute()
+		}
+	} else {
+		deleteFunc = func() (any, *http.Response, error) {
+			fR, fErr := c.Environment.Client.ManagementAPIClient.UsersApi.DeleteUser(ctx, c.Environment.EnvironmentID, user.GetId()).Execute()
+			return nil, fR, fErr
+		}
+	}
+
+	return clean.TryCleanConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		clean.ConfigItem{
+			IdentifierToEvaluate: user.GetUsername(),
+			Id:                   user.GetId(),
+			Object:               user,
+			Enabled:              enabled,
+			CreatedAt:            user.CreatedAt,
+		},
+		clean.ConfigItemEval{
+			Rules:       c.Rules,
+			Selectors:   []clean.ConfigItemSelector{selector},
+			ActionLimit: limit,
+		},
+		deleteFunc,
+		disableFunc,
+	)
+}
+
+// scimFilter combines the configured filter with the configured populations into a single SCIM filter
+func (c *CleanEnvironmentUsersConfig) scimFilter(ctx context.Context, configKey string) (string, error) {
+	filters := make([]string, 0)
+
+	if c.Filter != "" {
+		filters = append(filters, fmt.Sprintf("(%s)", c.Filter))
+	}
+
+	if len(c.PopulationNames) > 0 {
+		var response *management.EntityArray
+		err := clean.ReadAllConfig(
+			ctx,
+			configKey,
+			c.Environment,
+			func() (any, *http.Response, error) {
+				return c.Environment.Client.ManagementAPIClient.PopulationsApi.ReadAllPopulations(ctx, c.Environment.EnvironmentID).Execute()
+			},
+			&response,
+		)
+		if err != nil {
+			return "", err
+		}
+
+		embedded := response.GetEmbedded()
+
+		populationFilters := make([]string, 0, len(c.PopulationNames))
+		for _, populationName := range c.PopulationNames {
+			found := false
+			for _, population := range embedded.GetPopulations() {
+				if population.GetName() == populationName {
+					populationFilters = append(populationFilters, fmt.Sprintf(`population.id eq "%s"`, population.GetId()))
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				return "", fmt.Errorf(`[%s] Population "%s" cannot be found in the target environment`, configKey, populationName)
+			}
+		}
+
+		filters = append(filters, fmt.Sprintf("(%s)", strings.Join(populationFilters, " or ")))
+	}
+
+	return strings.Join(filters, " and "), nil
+}
+
+func (c *CleanEnvironmentUsersConfig) selectorName(filter string) string {
+	criteria := make([]string, 0)
+
+	if filter != "" {
+		criteria = append(criteria, fmt.Sprintf("filter %s", filter))
+	}
+
+	if c.LastSignOnOlderThanDays > 0 {
+		criteria = append(criteria, fmt.Sprintf("last sign-on older than %d days", c.LastSignOnOlderThanDays))
+	}
+
+	return strings.Join(criteria, ", ")
+}
+
+// signedOnBefore returns true if the user last signed on before the cutoff.  Users that have never signed on are judged by their creation time.
+func signedOnBefore(user management.User, cutoff time.Time) bool {
+	if lastSignOn, ok := user.GetLastSignOnOk(); ok && lastSignOn.At != nil {
+		return lastSignOn.At.Before(cutoff)
+	}
+
+	if createdAt, ok := user.GetCreatedAtOk(); ok {
+		return createdAt.Before(cutoff)
+	}
+
+	return false
+}
@@ -19,6 +19,7 @@ var (
 type CleanEnvironmentVerifyPoliciesConfig struct {
 	Environment                clean.CleanEnvironmentConfig
 	BootstrapVerifyPolicyNames []string
+	Rules                      []clean.Rule
 }
 
 func (c *CleanEnvironmentVerifyPoliciesConfig) Clean(ctx context.Context) error {
@@ -28,8 +29,8 @@ func (c *CleanEnvironmentVerifyPoliciesConfig) Clean(ctx context.Context) error
 
 	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
 
-	if len(c.BootstrapVerifyPolicyNames) == 0 {
-		l.Info().Msgf("[%s] No bootstrap names configured - skipping", configKey)
+	if len(c.BootstrapVerifyPolicyNames) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names or rules configured - skipping", configKey)
 		return nil
 	}
 
@@ -61,6 +62,7 @@ func (c *CleanEnvironmentVerifyPoliciesConfig) Clean(ctx context.Context) error
 
 		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
 		for _, policy := range embedded.GetVerifyPolicies() {
+			policy := policy
 
 			err := clean.TryCleanConfig(
 				ctx,
@@ -69,10 +71,14 @@ func (c *CleanEnvironmentVerifyPoliciesConfig) Clean(ctx context.Context) error
 				clean.ConfigItem{
 					IdentifierToEvaluate: policy.Name,
 					Id:                   *policy.Id,
+					Description:          policy.Description,
+					Object:               policy,
 					Default:              policy.Default,
+					CreatedAt:            policy.CreatedAt,
 				},
 				clean.ConfigItemEval{
 					IdentifierListToSearch: c.BootstrapVerifyPolicyNames,
+					Rules:                  c.Rules,
 					StartsWithStringMatch:  false,
 				},
 				func() (any, *http.Response, error) {
@@ -0,0 +1,237 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/verify"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type CleanEnvironmentVerifyVoicePhrasesConfig struct {
+	Environment                  clean.CleanEnvironmentConfig
+	BootstrapVoicePhraseNames    []string
+	BootstrapVoicePhrasePatterns []*regexp.Regexp
+	Rules                        []clean.Rule
+}
+
+func (c *CleanEnvironmentVerifyVoicePhrasesConfig) Clean(ctx context.Context) error {
+	l := logger.Get()
+
+	configKey := "Verify Voice Phrases"
+
+	l.Debug().Msgf(`[%s] Cleaning bootstrap config for environment ID "%s"..`, configKey, c.Environment.EnvironmentID)
+
+	if len(c.BootstrapVoicePhraseNames) == 0 && len(c.BootstrapVoicePhrasePatterns) == 0 && len(c.Rules) == 0 {
+		l.Info().Msgf("[%s] No bootstrap names, patterns or rules configured - skipping", configKey)
+		return nil
+	}
+
+	ok, err := clean.BillOfMaterialsHasService(ctx, configKey, c.Environment, management.ENUMPRODUCTTYPE_ONE_VERIFY)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		l.Info().Msgf("[%s] Bill of materials does not contain applicable service %s - skipping", configKey, management.ENUMPRODUCTTYPE_ONE_VERIFY)
+		return nil
+	}
+
+	var response *verify.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.VerifyAPIClient.VoicePhrasesApi.ReadAllVoicePhrases(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok && embedded.HasVoicePhrases() {
+
+		var references map[string][]string
+
+		l.Debug().Msgf("[%s] Configuration items found, looping..", configKey)
+		for _, voicePhrase := range embedded.GetVoicePhrases() {
+			voicePhrase := voicePhrase
+
+			// Contents are read at most once per phrase, for either the preview or the delete
+			var contents []verify.VoicePhraseContents
+			readContents := func() ([]verify.VoicePhraseContents, error) {
+				if contents == nil {
+					var err error
+					contents, err = c.readVoicePhraseContents(ctx, configKey, voicePhrase.GetId())
+					if err != nil {
+						return nil, err
+					}
+				}
+				return contents, nil
+			}
+
+			err := clean.TryCleanConfig(
+				ctx,
+				configKey,
+				c.Environment,
+				clean.ConfigItem{
+					IdentifierToEvaluate: voicePhrase.GetDisplayName(),
+					Id:                   voicePhrase.GetId(),
+					Object:               voicePhrase,
+					CreatedAt:            voicePhrase.CreatedAt,
+					BlockedBy: func() (*string, error) {
+						if references == nil {
+							var err error
+							references, err = c.readVerifyPolicyVoicePhraseReferences(ctx, configKey)
+							if err != nil {
+								return nil, err
+							}
+						}
+
+						if policies, ok := references[voicePhrase.GetId()]; ok {
+							reason := fmt.Sprintf(`the voice phrase is used by the voice settings of Verify policies "%s"`, strings.Join(policies, `", "`))
+							return &reason, nil
+						}
+
+						return nil, nil
+					},
+					Impact: func() (*string, error) {
+						contents, err := readContents()
+						if err != nil {
+							return nil, err
+						}
+
+						if len(contents) == 0 {
+							return nil, nil
+						}
+
+						locales := make([]string, 0, len(contents))
+						for _, content := range contents {
+							locales = append(locales, content.GetLocale())
+						}
+
+						impact := fmt.Sprintf("voice phrase contents for locales %s", strings.Join(locales, ", "))
+						return &impact, nil
+					},
+				},
+				clean.ConfigItemEval{
+					IdentifierListToSearch: c.BootstrapVoicePhraseNames,
+					PatternListToSearch:    c.BootstrapVoicePhrasePatterns,
+					Rules:                  c.Rules,
+					StartsWithStringMatch:  false,
+				},
+				func() (any, *http.Response, error) {
+					contents, err := readContents()
+					if err != nil {
+						return nil, nil, err
+					}
+
+					if err := c.deleteVoicePhraseContents(ctx, configKey, voicePhrase.GetId(), contents); err != nil {
+						return nil, nil, err
+					}
+
+					fR, fErr := c.Environment.Client.VerifyAPIClient.VoicePhrasesApi.DeleteVoicePhrase(ctx, c.Environment.EnvironmentID, voicePhrase.GetId()).Execute()
+					return nil, fR, fErr
+				},
+				nil,
+			)
+
+			if err != nil {
+				return err
+			}
+
+		}
+		l.Debug().Msgf("[%s] Done", configKey)
+
+	} else {
+		l.Debug().Msgf("[%s] No configuration items found in the target environment", configKey)
+	}
+
+	return nil
+}
+
+// deleteVoicePhraseContents removes the per-language contents of a voice phrase, so that the phrase itself can be removed
+func (c *CleanEnvironmentVerifyVoicePhrasesConfig) deleteVoicePhraseContents(ctx context.Context, configKey, voicePhraseID string, contents []verify.VoicePhraseContents) error {
+	for _, content := range contents {
+		content := content
+
+		err := sdk.ParseResponse(
+			ctx,
+			func() (any, *http.Response, error) {
+				fR, fErr := c.Environment.Client.VerifyAPIClient.VoicePhraseContentsApi.DeleteVoicePhraseContent(ctx, c.Environment.EnvironmentID, voicePhraseID, content.GetId()).Execute()
+				return nil, fR, fErr
+			},
+			fmt.Sprintf("[%s]-DELETECONTENT", configKey),
+			sdk.DefaultCreateReadRetryable,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *CleanEnvironmentVerifyVoicePhrasesConfig) readVoicePhraseContents(ctx context.Context, configKey, voicePhraseID string) ([]verify.VoicePhraseContents, error) {
+	var response *verify.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.VerifyAPIClient.VoicePhraseContentsApi.ReadAllVoicePhraseContents(ctx, c.Environment.EnvironmentID, voicePhraseID).Execute()
+		},
+		fmt.Sprintf("[%s]-READCONTENTS", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if embedded, ok := response.GetEmbeddedOk(); ok {
+		return embedded.GetContents(), nil
+	}
+
+	return make([]verify.VoicePhraseContents, 0), nil
+}
+
+// readVerifyPolicyVoicePhraseReferences returns the names of the Verify policies whose voice settings refer to each voice phrase, keyed by voice phrase ID
+func (c *CleanEnvironmentVerifyVoicePhrasesConfig) readVerifyPolicyVoicePhraseReferences(ctx context.Context, configKey string) (map[string][]string, error) {
+	references := make(map[string][]string)
+
+	var response *verify.EntityArray
+	err := sdk.ParseResponse(
+		ctx,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.VerifyAPIClient.VerifyPoliciesApi.ReadAllVerifyPolicies(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		fmt.Sprintf("[%s]-READVERIFYPOLICIES", configKey),
+		sdk.DefaultCreateReadRetryable,
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok {
+		return references, nil
+	}
+
+	for _, policy := range embedded.GetVerifyPolicies() {
+		if voice, ok := policy.GetVoiceOk(); ok && voice.TextDependent != nil {
+			voicePhraseID := voice.TextDependent.Phrase.GetId()
+			references[voicePhraseID] = append(references[voicePhraseID], policy.GetName())
+		}
+	}
+
+	return references, nil
+}
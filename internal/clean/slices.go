@@ -0,0 +1,23 @@
+package clean
+
+import "strings"
+
+// Contains returns true if the value is in the list
+func Contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsFold returns true if the value is in the list, compared case insensitively
+func ContainsFold[T ~string](values []T, value T) bool {
+	for _, v := range values {
+		if strings.EqualFold(string(v), string(value)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package clean
+
+import "testing"
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		value    string
+		expected bool
+		fold     bool
+	}{
+		{name: "exact match", values: []string{"a", "b"}, value: "b", expected: true, fold: true},
+		{name: "case differs", values: []string{"en-GB"}, value: "en-gb", expected: false, fold: true},
+		{name: "not present", values: []string{"a"}, value: "c", expected: false, fold: false},
+		{name: "empty list", values: nil, value: "a", expected: false, fold: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Contains(tt.values, tt.value); got != tt.expected {
+				t.Errorf("Contains() = %t, expected %t", got, tt.expected)
+			}
+			if got := ContainsFold(tt.values, tt.value); got != tt.fold {
+				t.Errorf("ContainsFold() = %t, expected %t", got, tt.fold)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package harden
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+)
+
+var (
+	// The response types that are only used by each grant type, removed with the grant type
+	grantTypeResponseTypes = map[management.EnumApplicationOIDCGrantType][]management.EnumApplicationOIDCResponseType{
+		management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE: {management.ENUMAPPLICATIONOIDCRESPONSETYPE_CODE},
+		management.ENUMAPPLICATIONOIDCGRANTTYPE_IMPLICIT:           {management.ENUMAPPLICATIONOIDCRESPONSETYPE_TOKEN, management.ENUMAPPLICATIONOIDCRESPONSETYPE_ID_TOKEN},
+	}
+
+	// The grant types that issue refresh tokens.  The device code and CIBA grant types are not in the SDK's enum, so are given by value.
+	refreshTokenIssuingGrantTypes = []management.EnumApplicationOIDCGrantType{
+		management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE,
+		management.EnumApplicationOIDCGrantType("DEVICE_CODE"),
+		management.EnumApplicationOIDCGrantType("CIBA"),
+	}
+)
+
+func (c *HardenEnvironmentConfig) checkApplications(ctx context.Context) ([]Finding, error) {
+	l := logger.Get()
+
+	configKey := "Applications"
+	baseline := c.Baseline.Applications
+
+	var response *management.EntityArray
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.ApplicationsApi.ReadAllApplications(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]Finding, 0)
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok {
+		return findings, nil
+	}
+
+	for _, applicationInner := range embedded.GetApplications() {
+		// Token lifetimes and grant types only apply to OIDC applications
+		if applicationInner.ApplicationOIDC == nil {
+			continue
+		}
+
+		application := *applicationInner.ApplicationOIDC
+
+		if clean.IsSystemApplication(application.GetType()) {
+			l.Debug().Msgf(`[%s] Skipping PingOne system application "%s" (%s)`, configKey, application.GetName(), application.GetType())
+			continue
+		}
+
+		if application.GetId() == c.WorkerClientID {
+			l.Debug().Msgf(`[%s] Skipping the worker application "%s" used to run the sweep`, configKey, application.GetName())
+			continue
+		}
+
+		if c.Environment.Protection.IsProtected(clean.ConfigItem{IdentifierToEvaluate: application.GetName(), Id: application.GetId(), Description: application.Description}) {
+			l.Debug().Msgf(`[%s] Skipping protected application "%s"`, configKey, application.GetName())
+			continue
+		}
+
+		hardened, messages, enforceable := hardenApplication(baseline, application)
+
+		for _, message := range messages {
+			finding := Finding{
+				Check:      configKey,
+				Severity:   baseline.Severity,
+				Resource:   application.GetName(),
+				ResourceID: application.GetId(),
+				Message:    message,
+			}
+
+			if enforceable {
+				finding.enforceKey = application.GetId()
+				finding.enforce = func() (any, *http.Response, error) {
+					var update management.ApplicationOIDC
+					if err := writableUpdate(hardened, &update); err != nil {
+						return nil, nil, err
+					}
+
+					return c.Environment.Client.ManagementAPIClient.ApplicationsApi.UpdateApplication(ctx, c.Environment.EnvironmentID, application.GetId()).UpdateApplicationRequest(management.UpdateApplicationRequest{
+						ApplicationOIDC: &update,
+					}).Execute()
+				}
+			}
+
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, nil
+}
+
+// hardenApplication compares an OIDC application's grant types and refresh token lifetimes to the baseline, returning the corrected application and
+// whether it can be enforced
+func hardenApplication(baseline ApplicationBaseline, application management.ApplicationOIDC) (management.ApplicationOIDC, []string, bool) {
+	hardened := application
+	hardened.GrantTypes = append([]management.EnumApplicationOIDCGrantType{}, application.GrantTypes...)
+	hardened.ResponseTypes = append([]management.EnumApplicationOIDCResponseType{}, application.ResponseTypes...)
+
+	messages := make([]string, 0)
+	enforceable := true
+
+	for _, grantType := range application.GetGrantTypes() {
+		if clean.ContainsFold(baseline.DisallowedGrantTypes, string(grantType)) {
+			messages = append(messages, fmt.Sprintf("the %s grant type is enabled", grantType))
+			removeGrantType(&hardened, grantType)
+		}
+	}
+
+	// A refresh token grant is never used without a grant type that issues refresh tokens
+	if baseline.DisableUnusedGrantTypes && clean.ContainsFold(hardened.GrantTypes, management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN) && !issuesRefreshTokens(hardened.GrantTypes) {
+		messages = append(messages, fmt.Sprintf("the %s grant type is enabled without a grant type that issues refresh tokens, so it is never used", management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN))
+		removeGrantType(&hardened, management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN)
+	}
+
+	if clean.ContainsFold(hardened.GrantTypes, management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN) {
+		if baseline.MaxRefreshTokenDurationSeconds > 0 && application.GetRefreshTokenDuration() > baseline.MaxRefreshTokenDurationSeconds {
+			messages = append(messages, fmt.Sprintf("refresh tokens last %d seconds, more than the baseline of %d", application.GetRefreshTokenDuration(), baseline.MaxRefreshTokenDurationSeconds))
+			hardened.RefreshTokenDuration = &baseline.MaxRefreshTokenDurationSeconds
+		}
+
+		if baseline.MaxRefreshTokenRollingDurationSeconds > 0 && application.GetRefreshTokenRollingDuration() > baseline.MaxRefreshTokenRollingDurationSeconds {
+			messages = append(messages, fmt.Sprintf("refresh tokens roll over for %d seconds, more than the baseline of %d", application.GetRefreshTokenRollingDuration(), baseline.MaxRefreshTokenRollingDurationSeconds))
+			hardened.RefreshTokenRollingDuration = &baseline.MaxRefreshTokenRollingDurationSeconds
+		}
+
+		// PingOne requires the rolling duration to be at least the refresh token duration
+		if hardened.GetRefreshTokenRollingDuration() < hardened.GetRefreshTokenDuration() {
+			enforceable = false
+		}
+	}
+
+	// An application must keep at least one grant type, so removing the last one is left to an administrator
+	if len(hardened.GrantTypes) == 0 {
+		enforceable = false
+	}
+
+	return hardened, messages, enforceable
+}
+
+func issuesRefreshTokens(grantTypes []management.EnumApplicationOIDCGrantType) bool {
+	for _, grantType := range refreshTokenIssuingGrantTypes {
+		if clean.Contains(grantTypes, grantType) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeGrantType removes a grant type, and the response types only used by it, from an application
+func removeGrantType(application *management.ApplicationOIDC, grantType management.EnumApplicationOIDCGrantType) {
+	grantTypes := make([]management.EnumApplicationOIDCGrantType, 0, len(application.GrantTypes))
+	for _, v := range application.GrantTypes {
+		if v != grantType {
+			grantTypes = append(grantTypes, v)
+		}
+	}
+	application.GrantTypes = grantTypes
+
+	responseTypes := make([]management.EnumApplicationOIDCResponseType, 0, len(application.ResponseTypes))
+	for _, v := range application.ResponseTypes {
+		used := false
+		for _, responseType := range grantTypeResponseTypes[grantType] {
+			used = used || v == responseType
+		}
+
+		if !used {
+			responseTypes = append(responseTypes, v)
+		}
+	}
+	application.ResponseTypes = responseTypes
+}
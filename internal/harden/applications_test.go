@@ -0,0 +1,170 @@
+package harden
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+func TestRemoveGrantType(t *testing.T) {
+
+	tests := []struct {
+		name                  string
+		grantTypes            []management.EnumApplicationOIDCGrantType
+		responseTypes         []management.EnumApplicationOIDCResponseType
+		remove                management.EnumApplicationOIDCGrantType
+		expectedGrantTypes    []management.EnumApplicationOIDCGrantType
+		expectedResponseTypes []management.EnumApplicationOIDCResponseType
+	}{
+		{
+			name:                  "the implicit grant type is removed with its response types",
+			grantTypes:            []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE, management.ENUMAPPLICATIONOIDCGRANTTYPE_IMPLICIT},
+			responseTypes:         []management.EnumApplicationOIDCResponseType{management.ENUMAPPLICATIONOIDCRESPONSETYPE_CODE, management.ENUMAPPLICATIONOIDCRESPONSETYPE_TOKEN, management.ENUMAPPLICATIONOIDCRESPONSETYPE_ID_TOKEN},
+			remove:                management.ENUMAPPLICATIONOIDCGRANTTYPE_IMPLICIT,
+			expectedGrantTypes:    []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE},
+			expectedResponseTypes: []management.EnumApplicationOIDCResponseType{management.ENUMAPPLICATIONOIDCRESPONSETYPE_CODE},
+		},
+		{
+			name:                  "a grant type without response types keeps the response types",
+			grantTypes:            []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE, management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN},
+			responseTypes:         []management.EnumApplicationOIDCResponseType{management.ENUMAPPLICATIONOIDCRESPONSETYPE_CODE},
+			remove:                management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN,
+			expectedGrantTypes:    []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE},
+			expectedResponseTypes: []management.EnumApplicationOIDCResponseType{management.ENUMAPPLICATIONOIDCRESPONSETYPE_CODE},
+		},
+		{
+			name:                  "removing the last grant type leaves none",
+			grantTypes:            []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE},
+			responseTypes:         []management.EnumApplicationOIDCResponseType{management.ENUMAPPLICATIONOIDCRESPONSETYPE_CODE},
+			remove:                management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE,
+			expectedGrantTypes:    []management.EnumApplicationOIDCGrantType{},
+			expectedResponseTypes: []management.EnumApplicationOIDCResponseType{},
+		},
+		{
+			name:                  "a grant type that is not enabled changes nothing",
+			grantTypes:            []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_CLIENT_CREDENTIALS},
+			responseTypes:         []management.EnumApplicationOIDCResponseType{},
+			remove:                management.ENUMAPPLICATIONOIDCGRANTTYPE_IMPLICIT,
+			expectedGrantTypes:    []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_CLIENT_CREDENTIALS},
+			expectedResponseTypes: []management.EnumApplicationOIDCResponseType{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			application := management.ApplicationOIDC{
+				GrantTypes:    tt.grantTypes,
+				ResponseTypes: tt.responseTypes,
+			}
+
+			removeGrantType(&application, tt.remove)
+
+			if !reflect.DeepEqual(application.GrantTypes, tt.expectedGrantTypes) {
+				t.Errorf("expected grant types %v, got %v", tt.expectedGrantTypes, application.GrantTypes)
+			}
+			if !reflect.DeepEqual(application.ResponseTypes, tt.expectedResponseTypes) {
+				t.Errorf("expected response types %v, got %v", tt.expectedResponseTypes, application.ResponseTypes)
+			}
+		})
+	}
+}
+
+func TestHardenApplication(t *testing.T) {
+
+	baseline := ApplicationBaseline{
+		MaxRefreshTokenDurationSeconds:        3600,
+		MaxRefreshTokenRollingDurationSeconds: 7200,
+		DisableUnusedGrantTypes:               true,
+	}
+
+	tests := []struct {
+		name                        string
+		grantTypes                  []management.EnumApplicationOIDCGrantType
+		refreshTokenDuration        int32
+		refreshTokenRollingDuration int32
+		expectedMessages            int
+		expectedGrantTypes          []management.EnumApplicationOIDCGrantType
+		expectedEnforceable         bool
+	}{
+		{
+			name:                        "a refresh token grant without a grant type that issues refresh tokens is removed",
+			grantTypes:                  []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_CLIENT_CREDENTIALS, management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN},
+			refreshTokenDuration:        3600,
+			refreshTokenRollingDuration: 7200,
+			expectedMessages:            1,
+			expectedGrantTypes:          []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_CLIENT_CREDENTIALS},
+			expectedEnforceable:         true,
+		},
+		{
+			name:                        "a refresh token grant with the device code grant is kept",
+			grantTypes:                  []management.EnumApplicationOIDCGrantType{management.EnumApplicationOIDCGrantType("DEVICE_CODE"), management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN},
+			refreshTokenDuration:        3600,
+			refreshTokenRollingDuration: 7200,
+			expectedMessages:            0,
+			expectedGrantTypes:          []management.EnumApplicationOIDCGrantType{management.EnumApplicationOIDCGrantType("DEVICE_CODE"), management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN},
+			expectedEnforceable:         true,
+		},
+		{
+			name:                        "a refresh token grant with the CIBA grant is kept",
+			grantTypes:                  []management.EnumApplicationOIDCGrantType{management.EnumApplicationOIDCGrantType("CIBA"), management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN},
+			refreshTokenDuration:        3600,
+			refreshTokenRollingDuration: 7200,
+			expectedMessages:            0,
+			expectedGrantTypes:          []management.EnumApplicationOIDCGrantType{management.EnumApplicationOIDCGrantType("CIBA"), management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN},
+			expectedEnforceable:         true,
+		},
+		{
+			name:                        "refresh token lifetimes over the baseline are lowered",
+			grantTypes:                  []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE, management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN},
+			refreshTokenDuration:        86400,
+			refreshTokenRollingDuration: 172800,
+			expectedMessages:            2,
+			expectedGrantTypes:          []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE, management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN},
+			expectedEnforceable:         true,
+		},
+		{
+			name:                        "a rolling duration below the lowered refresh token duration is not enforceable",
+			grantTypes:                  []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE, management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN},
+			refreshTokenDuration:        86400,
+			refreshTokenRollingDuration: 1800,
+			expectedMessages:            1,
+			expectedGrantTypes:          []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_AUTHORIZATION_CODE, management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN},
+			expectedEnforceable:         false,
+		},
+		{
+			name:                        "removing the last grant type is not enforceable",
+			grantTypes:                  []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_REFRESH_TOKEN},
+			refreshTokenDuration:        3600,
+			refreshTokenRollingDuration: 7200,
+			expectedMessages:            1,
+			expectedGrantTypes:          []management.EnumApplicationOIDCGrantType{},
+			expectedEnforceable:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			application := management.ApplicationOIDC{
+				GrantTypes:                  tt.grantTypes,
+				RefreshTokenDuration:        &tt.refreshTokenDuration,
+				RefreshTokenRollingDuration: &tt.refreshTokenRollingDuration,
+			}
+
+			hardened, messages, enforceable := hardenApplication(baseline, application)
+
+			if len(messages) != tt.expectedMessages {
+				t.Errorf("expected %d findings, got %d: %v", tt.expectedMessages, len(messages), messages)
+			}
+			if !reflect.DeepEqual(hardened.GrantTypes, tt.expectedGrantTypes) {
+				t.Errorf("expected grant types %v, got %v", tt.expectedGrantTypes, hardened.GrantTypes)
+			}
+			if enforceable != tt.expectedEnforceable {
+				t.Errorf("expected enforceable %t, got %t", tt.expectedEnforceable, enforceable)
+			}
+			if len(application.GrantTypes) != len(tt.grantTypes) {
+				t.Errorf("expected the application read from the API to be left unchanged, got grant types %v", application.GrantTypes)
+			}
+		})
+	}
+}
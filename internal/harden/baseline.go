@@ -0,0 +1,130 @@
+package harden
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Severity string
+
+const (
+	ENUMSEVERITY_HIGH   Severity = "high"
+	ENUMSEVERITY_MEDIUM Severity = "medium"
+	ENUMSEVERITY_LOW    Severity = "low"
+)
+
+// Baseline is the security configuration an environment is checked against.  A check whose limit is zero (or empty) is not applied.
+type Baseline struct {
+	PasswordPolicies   PasswordPolicyBaseline     `yaml:"password-policies"`
+	MFADevicePolicies  MFADevicePolicyBaseline    `yaml:"mfa-device-policies"`
+	Applications       ApplicationBaseline        `yaml:"applications"`
+	NotificationSender NotificationSenderBaseline `yaml:"notification-sender"`
+}
+
+type PasswordPolicyBaseline struct {
+	Enabled                   bool     `yaml:"enabled"`
+	Severity                  Severity `yaml:"severity"`
+	MaxLockoutFailureCount    int32    `yaml:"max-lockout-failure-count"`
+	MinLockoutDurationSeconds int32    `yaml:"min-lockout-duration-seconds"`
+	MinHistoryCount           int32    `yaml:"min-history-count"`
+	MinHistoryRetentionDays   int32    `yaml:"min-history-retention-days"`
+}
+
+type MFADevicePolicyBaseline struct {
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity"`
+	// The device methods (SMS, VOICE, EMAIL, MOBILE, TOTP) that must be disabled
+	DisabledMethods    []string `yaml:"disabled-methods"`
+	MaxOTPFailureCount int32    `yaml:"max-otp-failure-count"`
+}
+
+type ApplicationBaseline struct {
+	Enabled                               bool     `yaml:"enabled"`
+	Severity                              Severity `yaml:"severity"`
+	MaxRefreshTokenDurationSeconds        int32    `yaml:"max-refresh-token-duration-seconds"`
+	MaxRefreshTokenRollingDurationSeconds int32    `yaml:"max-refresh-token-rolling-duration-seconds"`
+	DisallowedGrantTypes                  []string `yaml:"disallowed-grant-types"`
+	// Require grant types that an application cannot use (such as a refresh token grant without a grant type that issues refresh tokens) to be disabled
+	DisableUnusedGrantTypes bool `yaml:"disable-unused-grant-types"`
+}
+
+type NotificationSenderBaseline struct {
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity"`
+	// Require a sender address other than the PingOne default
+	RequireCustomFromAddress bool   `yaml:"require-custom-from-address"`
+	FromAddress              string `yaml:"from-address"`
+	FromName                 string `yaml:"from-name"`
+}
+
+// DefaultBaseline returns the baseline used when no baseline file is given, and the values a baseline file overrides
+func DefaultBaseline() Baseline {
+	return Baseline{
+		PasswordPolicies: PasswordPolicyBaseline{
+			Enabled:                   true,
+			Severity:                  ENUMSEVERITY_HIGH,
+			MaxLockoutFailureCount:    5,
+			MinLockoutDurationSeconds: 900,
+			MinHistoryCount:           6,
+			MinHistoryRetentionDays:   365,
+		},
+		MFADevicePolicies: MFADevicePolicyBaseline{
+			Enabled:            true,
+			Severity:           ENUMSEVERITY_MEDIUM,
+			DisabledMethods:    []string{},
+			MaxOTPFailureCount: 3,
+		},
+		Applications: ApplicationBaseline{
+			Enabled:                               true,
+			Severity:                              ENUMSEVERITY_MEDIUM,
+			MaxRefreshTokenDurationSeconds:        2592000,
+			MaxRefreshTokenRollingDurationSeconds: 15552000,
+			DisallowedGrantTypes:                  []string{"IMPLICIT"},
+			DisableUnusedGrantTypes:               true,
+		},
+		NotificationSender: NotificationSenderBaseline{
+			Enabled:                  true,
+			Severity:                 ENUMSEVERITY_LOW,
+			RequireCustomFromAddress: true,
+		},
+	}
+}
+
+// ReadBaselineFile reads a baseline from a YAML file over the default baseline, so that a file only needs to contain the values that differ
+func ReadBaselineFile(path string) (Baseline, error) {
+	baseline := DefaultBaseline()
+
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return baseline, fmt.Errorf("Cannot read the baseline file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(fileBytes, &baseline); err != nil {
+		return baseline, fmt.Errorf("Cannot parse the baseline file: %w", err)
+	}
+
+	if err := baseline.validate(); err != nil {
+		return baseline, err
+	}
+
+	return baseline, nil
+}
+
+func (b Baseline) validate() error {
+	for check, severity := range map[string]Severity{
+		"password-policies":   b.PasswordPolicies.Severity,
+		"mfa-device-policies": b.MFADevicePolicies.Severity,
+		"applications":        b.Applications.Severity,
+		"notification-sender": b.NotificationSender.Severity,
+	} {
+		switch severity {
+		case ENUMSEVERITY_HIGH, ENUMSEVERITY_MEDIUM, ENUMSEVERITY_LOW:
+		default:
+			return fmt.Errorf("Invalid severity \"%s\" for the %s baseline - must be one of %s, %s or %s", severity, check, ENUMSEVERITY_HIGH, ENUMSEVERITY_MEDIUM, ENUMSEVERITY_LOW)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package harden
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+	"github.com/patrickcping/pingone-sweep/internal/logger"
+	"github.com/patrickcping/pingone-sweep/internal/sdk"
+)
+
+type HardenEnvironmentConfig struct {
+	Environment clean.CleanEnvironmentConfig
+	Baseline    Baseline
+	Enforce     bool
+	// WorkerClientID is the worker application running the sweep, which is never modified
+	WorkerClientID string
+}
+
+// Finding is a difference between the environment and the baseline.  Findings that share an enforce function (for example, several settings of the same
+// password policy) are enforced together with a single update.
+type Finding struct {
+	Check      string
+	Severity   Severity
+	Resource   string
+	ResourceID string
+	Message    string
+	enforceKey string
+	enforce    sdk.SDKInterfaceFunc
+}
+
+// Enforceable returns true if the finding can be corrected automatically
+func (f Finding) Enforceable() bool {
+	return f.enforce != nil
+}
+
+// Harden checks the environment against the baseline and, if enforcement is switched on, corrects the findings that can be corrected automatically.
+// The findings are returned whether or not they are enforced.
+func (c *HardenEnvironmentConfig) Harden(ctx context.Context) ([]Finding, error) {
+	l := logger.Get()
+
+	l.Debug().Msgf(`Checking environment ID "%s" against the hardening baseline..`, c.Environment.EnvironmentID)
+
+	checks := []struct {
+		enabled bool
+		check   func(context.Context) ([]Finding, error)
+	}{
+		{c.Baseline.PasswordPolicies.Enabled, c.checkPasswordPolicies},
+		{c.Baseline.MFADevicePolicies.Enabled, c.checkMFADevicePolicies},
+		{c.Baseline.Applications.Enabled, c.checkApplications},
+		{c.Baseline.NotificationSender.Enabled, c.checkNotificationSender},
+	}
+
+	findings := make([]Finding, 0)
+	for _, v := range checks {
+		if !v.enabled {
+			continue
+		}
+
+		checkFindings, err := v.check(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		findings = append(findings, checkFindings...)
+	}
+
+	enforced := make(map[string]bool)
+	for _, finding := range findings {
+		printFinding(finding, c.Environment.DryRun)
+
+		if !c.Enforce || !finding.Enforceable() || enforced[finding.enforceKey] {
+			continue
+		}
+
+		enforced[finding.enforceKey] = true
+
+		if c.Environment.DryRun {
+			l.Warn().Msgf(`[%s] Dry run: enforce baseline for "%s" with ID "%s"`, finding.Check, finding.Resource, finding.ResourceID)
+			continue
+		}
+
+		err := sdk.ParseResponse(
+			ctx,
+			finding.enforce,
+			fmt.Sprintf("[%s]-ENFORCE", finding.Check),
+			sdk.DefaultCreateReadRetryable,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		l.Info().Msgf(`[%s] Baseline enforced for "%s"`, finding.Check, finding.Resource)
+	}
+
+	printSummary(findings)
+
+	return findings, nil
+}
+
+// writableUpdate decodes the writable settings of a corrected configuration object into target, so that the read only metadata set by PingOne is not
+// sent back in the update
+func writableUpdate(v any, target any) error {
+	settings, err := clean.WritableSettings(v)
+	if err != nil {
+		return err
+	}
+
+	updateBytes, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(updateBytes, target)
+}
+
+func printFinding(finding Finding, dryRun bool) {
+	checkFormat := color.New(color.FgBlue, color.Bold).SprintFunc()
+	printString := checkFormat(finding.Check)
+
+	if dryRun {
+		dryRunFormat := color.New(color.FgMagenta).SprintFunc()
+		printString = fmt.Sprintf("%s %s%s%s", printString, checkFormat("("), dryRunFormat("DRY RUN"), checkFormat(")"))
+	}
+
+	printString = fmt.Sprintf("%s %s - %s (%s): %s", formatSeverity(finding.Severity), printString, finding.Resource, finding.ResourceID, finding.Message)
+
+	if !finding.Enforceable() {
+		printString = fmt.Sprintf("%s %s", printString, color.CyanString("(manual)"))
+	}
+
+	fmt.Printf("%s\n", printString)
+}
+
+func printSummary(findings []Finding) {
+	counts := make(map[Severity]int)
+	for _, finding := range findings {
+		counts[finding.Severity]++
+	}
+
+	if len(findings) == 0 {
+		fmt.Printf("%s\n", color.GreenString("The environment meets the hardening baseline"))
+		return
+	}
+
+	fmt.Printf("%d findings: %d %s, %d %s, %d %s\n", len(findings), counts[ENUMSEVERITY_HIGH], ENUMSEVERITY_HIGH, counts[ENUMSEVERITY_MEDIUM], ENUMSEVERITY_MEDIUM, counts[ENUMSEVERITY_LOW], ENUMSEVERITY_LOW)
+}
+
+func formatSeverity(severity Severity) string {
+	label := fmt.Sprintf("[%s]", severity)
+
+	switch severity {
+	case ENUMSEVERITY_HIGH:
+		return color.RedString(label)
+	case ENUMSEVERITY_MEDIUM:
+		return color.YellowString(label)
+	default:
+		return color.CyanString(label)
+	}
+}
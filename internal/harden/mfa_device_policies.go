@@ -0,0 +1,106 @@
+package harden
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/mfa"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+)
+
+// deviceMethod is a device method of an MFA device policy, with pointers into the policy so that it can be corrected
+type deviceMethod struct {
+	name    string
+	enabled *bool
+	failure *mfa.DeviceAuthenticationPolicyOfflineDeviceOtpFailure
+}
+
+func (c *HardenEnvironmentConfig) checkMFADevicePolicies(ctx context.Context) ([]Finding, error) {
+	configKey := "MFA Device Policies"
+	baseline := c.Baseline.MFADevicePolicies
+
+	ok, err := clean.BillOfMaterialsHasService(ctx, configKey, c.Environment, management.ENUMPRODUCTTYPE_ONE_MFA)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]Finding, 0)
+
+	if !ok {
+		return findings, nil
+	}
+
+	var response *mfa.EntityArray
+	err = clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.MFAAPIClient.DeviceAuthenticationPolicyApi.ReadDeviceAuthenticationPolicies(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok {
+		return findings, nil
+	}
+
+	for _, policy := range embedded.GetDeviceAuthenticationPolicies() {
+		policy := policy
+
+		hardened := policy
+		methods := []deviceMethod{
+			{"SMS", &hardened.Sms.Enabled, &hardened.Sms.Otp.Failure},
+			{"VOICE", &hardened.Voice.Enabled, &hardened.Voice.Otp.Failure},
+			{"EMAIL", &hardened.Email.Enabled, &hardened.Email.Otp.Failure},
+			{"MOBILE", &hardened.Mobile.Enabled, &hardened.Mobile.Otp.Failure},
+			{"TOTP", &hardened.Totp.Enabled, &hardened.Totp.Otp.Failure},
+		}
+
+		messages := make([]string, 0)
+
+		for _, method := range methods {
+			if !*method.enabled {
+				continue
+			}
+
+			if clean.ContainsFold(baseline.DisabledMethods, method.name) {
+				messages = append(messages, fmt.Sprintf("the %s device method is enabled", method.name))
+				*method.enabled = false
+				continue
+			}
+
+			if baseline.MaxOTPFailureCount > 0 && method.failure.Count > baseline.MaxOTPFailureCount {
+				messages = append(messages, fmt.Sprintf("the %s device method allows %d OTP failures, more than the baseline of %d", method.name, method.failure.Count, baseline.MaxOTPFailureCount))
+				method.failure.Count = baseline.MaxOTPFailureCount
+			}
+		}
+
+		for _, message := range messages {
+			findings = append(findings, Finding{
+				Check:      configKey,
+				Severity:   baseline.Severity,
+				Resource:   policy.GetName(),
+				ResourceID: policy.GetId(),
+				Message:    message,
+				enforceKey: policy.GetId(),
+				enforce: func() (any, *http.Response, error) {
+					var update mfa.DeviceAuthenticationPolicy
+					if err := writableUpdate(hardened, &update); err != nil {
+						return nil, nil, err
+					}
+
+					return c.Environment.Client.MFAAPIClient.DeviceAuthenticationPolicyApi.UpdateDeviceAuthenticationPolicy(ctx, c.Environment.EnvironmentID, policy.GetId()).DeviceAuthenticationPolicy(update).Execute()
+				},
+			})
+		}
+	}
+
+	return findings, nil
+}
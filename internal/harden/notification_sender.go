@@ -0,0 +1,100 @@
+package harden
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+)
+
+const (
+	// The sender address PingOne uses when no sender is configured
+	defaultNotificationFromAddress = "noreply@pingidentity.com"
+)
+
+func (c *HardenEnvironmentConfig) checkNotificationSender(ctx context.Context) ([]Finding, error) {
+	configKey := "Notification Sender"
+	baseline := c.Baseline.NotificationSender
+
+	var response *management.NotificationsSettings
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.NotificationsSettingsApi.ReadNotificationsSettings(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]Finding, 0)
+
+	if response == nil {
+		return findings, nil
+	}
+
+	from := management.NotificationsSettingsFrom{}
+	if v, ok := response.GetFromOk(); ok {
+		from = *v
+	}
+
+	// The sender can only be corrected when the baseline says what it should be
+	type senderMessage struct {
+		message     string
+		enforceable bool
+	}
+	messages := make([]senderMessage, 0)
+
+	currentAddress := from.GetAddress()
+	if baseline.RequireCustomFromAddress && (currentAddress == "" || strings.EqualFold(currentAddress, defaultNotificationFromAddress)) {
+		messages = append(messages, senderMessage{"notifications are sent from the PingOne default sender address", baseline.FromAddress != ""})
+	} else if baseline.FromAddress != "" && !strings.EqualFold(currentAddress, baseline.FromAddress) {
+		messages = append(messages, senderMessage{fmt.Sprintf(`notifications are sent from "%s" rather than the baseline sender "%s"`, currentAddress, baseline.FromAddress), true})
+	}
+
+	if baseline.FromName != "" && from.GetName() != baseline.FromName {
+		messages = append(messages, senderMessage{fmt.Sprintf(`notifications are sent with the sender name "%s" rather than the baseline name "%s"`, from.GetName(), baseline.FromName), true})
+	}
+
+	hardened := *response
+	hardenedFrom := from
+	if baseline.FromAddress != "" {
+		hardenedFrom.Address = &baseline.FromAddress
+	}
+	if baseline.FromName != "" {
+		hardenedFrom.Name = &baseline.FromName
+	}
+	hardened.From = &hardenedFrom
+
+	for _, v := range messages {
+		finding := Finding{
+			Check:      configKey,
+			Severity:   baseline.Severity,
+			Resource:   "Notification settings",
+			ResourceID: c.Environment.EnvironmentID,
+			Message:    v.message,
+		}
+
+		if v.enforceable {
+			finding.enforceKey = c.Environment.EnvironmentID
+			finding.enforce = func() (any, *http.Response, error) {
+				var update management.NotificationsSettings
+				if err := writableUpdate(hardened, &update); err != nil {
+					return nil, nil, err
+				}
+
+				return c.Environment.Client.ManagementAPIClient.NotificationsSettingsApi.UpdateNotificationsSettings(ctx, c.Environment.EnvironmentID).NotificationsSettings(update).Execute()
+			}
+		}
+
+		findings = append(findings, finding)
+	}
+
+	return findings, nil
+}
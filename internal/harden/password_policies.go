@@ -0,0 +1,109 @@
+package harden
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-sweep/internal/clean"
+)
+
+func (c *HardenEnvironmentConfig) checkPasswordPolicies(ctx context.Context) ([]Finding, error) {
+	configKey := "Password Policies"
+	baseline := c.Baseline.PasswordPolicies
+
+	var response *management.EntityArray
+	err := clean.ReadAllConfig(
+		ctx,
+		configKey,
+		c.Environment,
+		func() (any, *http.Response, error) {
+			return c.Environment.Client.ManagementAPIClient.PasswordPoliciesApi.ReadAllPasswordPolicies(ctx, c.Environment.EnvironmentID).Execute()
+		},
+		&response,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]Finding, 0)
+
+	embedded, ok := response.GetEmbeddedOk()
+	if !ok {
+		return findings, nil
+	}
+
+	for _, policy := range embedded.GetPasswordPolicies() {
+		policy := policy
+
+		hardened, messages := hardenPasswordPolicy(baseline, policy)
+
+		for _, message := range messages {
+			findings = append(findings, Finding{
+				Check:      configKey,
+				Severity:   baseline.Severity,
+				Resource:   policy.GetName(),
+				ResourceID: policy.GetId(),
+				Message:    message,
+				enforceKey: policy.GetId(),
+				enforce: func() (any, *http.Response, error) {
+					var update management.PasswordPolicy
+					if err := writableUpdate(hardened, &update); err != nil {
+						return nil, nil, err
+					}
+
+					return c.Environment.Client.ManagementAPIClient.PasswordPoliciesApi.UpdatePasswordPolicy(ctx, c.Environment.EnvironmentID, policy.GetId()).PasswordPolicy(update).Execute()
+				},
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// hardenPasswordPolicy compares a password policy's lockout and history to the baseline.  The corrected policy is built up alongside the findings, so
+// that all of the policy's findings are enforced with one update.
+func hardenPasswordPolicy(baseline PasswordPolicyBaseline, policy management.PasswordPolicy) (management.PasswordPolicy, []string) {
+	hardened := policy
+	lockout := management.PasswordPolicyLockout{}
+	if v, ok := policy.GetLockoutOk(); ok {
+		lockout = *v
+	}
+	history := management.PasswordPolicyHistory{}
+	if v, ok := policy.GetHistoryOk(); ok {
+		history = *v
+	}
+
+	messages := make([]string, 0)
+
+	if baseline.MaxLockoutFailureCount > 0 {
+		if v, ok := lockout.GetFailureCountOk(); !ok {
+			messages = append(messages, "account lockout is not enabled")
+			lockout.FailureCount = &baseline.MaxLockoutFailureCount
+		} else if *v > baseline.MaxLockoutFailureCount {
+			messages = append(messages, fmt.Sprintf("account lockout allows %d failures, more than the baseline of %d", *v, baseline.MaxLockoutFailureCount))
+			lockout.FailureCount = &baseline.MaxLockoutFailureCount
+		}
+	}
+
+	if baseline.MinLockoutDurationSeconds > 0 && lockout.FailureCount != nil && lockout.GetDurationSeconds() < baseline.MinLockoutDurationSeconds {
+		messages = append(messages, fmt.Sprintf("account lockout lasts %d seconds, less than the baseline of %d", lockout.GetDurationSeconds(), baseline.MinLockoutDurationSeconds))
+		lockout.DurationSeconds = &baseline.MinLockoutDurationSeconds
+	}
+
+	if baseline.MinHistoryCount > 0 && history.GetCount() < baseline.MinHistoryCount {
+		messages = append(messages, fmt.Sprintf("password history remembers %d passwords, less than the baseline of %d", history.GetCount(), baseline.MinHistoryCount))
+		history.Count = &baseline.MinHistoryCount
+	}
+
+	if baseline.MinHistoryRetentionDays > 0 && history.GetRetentionDays() < baseline.MinHistoryRetentionDays {
+		messages = append(messages, fmt.Sprintf("password history is kept for %d days, less than the baseline of %d", history.GetRetentionDays(), baseline.MinHistoryRetentionDays))
+		history.RetentionDays = &baseline.MinHistoryRetentionDays
+	}
+
+	hardened.Lockout = &lockout
+	hardened.History = &history
+
+	return hardened, messages
+}
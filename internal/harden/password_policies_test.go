@@ -0,0 +1,103 @@
+package harden
+
+import (
+	"testing"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+func TestHardenPasswordPolicy(t *testing.T) {
+
+	baseline := PasswordPolicyBaseline{
+		MaxLockoutFailureCount:    5,
+		MinLockoutDurationSeconds: 900,
+		MinHistoryCount:           6,
+		MinHistoryRetentionDays:   365,
+	}
+
+	tests := []struct {
+		name             string
+		lockout          *management.PasswordPolicyLockout
+		history          *management.PasswordPolicyHistory
+		expectedMessages int
+		check            func(*testing.T, management.PasswordPolicy)
+	}{
+		{
+			name:             "a policy that meets the baseline has no findings",
+			lockout:          &management.PasswordPolicyLockout{FailureCount: management.PtrInt32(3), DurationSeconds: management.PtrInt32(900)},
+			history:          &management.PasswordPolicyHistory{Count: management.PtrInt32(6), RetentionDays: management.PtrInt32(365)},
+			expectedMessages: 0,
+		},
+		{
+			name:             "lockout that is not enabled is enabled with the baseline failure count and duration",
+			history:          &management.PasswordPolicyHistory{Count: management.PtrInt32(6), RetentionDays: management.PtrInt32(365)},
+			expectedMessages: 2,
+			check: func(t *testing.T, hardened management.PasswordPolicy) {
+				if hardened.Lockout.GetFailureCount() != 5 {
+					t.Errorf("expected failure count 5, got %d", hardened.Lockout.GetFailureCount())
+				}
+				if hardened.Lockout.GetDurationSeconds() != 900 {
+					t.Errorf("expected duration 900, got %d", hardened.Lockout.GetDurationSeconds())
+				}
+			},
+		},
+		{
+			name:             "a failure count over the baseline is lowered and the duration is kept",
+			lockout:          &management.PasswordPolicyLockout{FailureCount: management.PtrInt32(10), DurationSeconds: management.PtrInt32(1800)},
+			history:          &management.PasswordPolicyHistory{Count: management.PtrInt32(6), RetentionDays: management.PtrInt32(365)},
+			expectedMessages: 1,
+			check: func(t *testing.T, hardened management.PasswordPolicy) {
+				if hardened.Lockout.GetFailureCount() != 5 {
+					t.Errorf("expected failure count 5, got %d", hardened.Lockout.GetFailureCount())
+				}
+				if hardened.Lockout.GetDurationSeconds() != 1800 {
+					t.Errorf("expected duration 1800 to be kept, got %d", hardened.Lockout.GetDurationSeconds())
+				}
+			},
+		},
+		{
+			name:             "history under the baseline is raised",
+			lockout:          &management.PasswordPolicyLockout{FailureCount: management.PtrInt32(5), DurationSeconds: management.PtrInt32(900)},
+			history:          &management.PasswordPolicyHistory{Count: management.PtrInt32(2), RetentionDays: management.PtrInt32(30)},
+			expectedMessages: 2,
+			check: func(t *testing.T, hardened management.PasswordPolicy) {
+				if hardened.History.GetCount() != 6 {
+					t.Errorf("expected history count 6, got %d", hardened.History.GetCount())
+				}
+				if hardened.History.GetRetentionDays() != 365 {
+					t.Errorf("expected retention 365 days, got %d", hardened.History.GetRetentionDays())
+				}
+			},
+		},
+		{
+			name:             "history that is not set is set to the baseline",
+			lockout:          &management.PasswordPolicyLockout{FailureCount: management.PtrInt32(5), DurationSeconds: management.PtrInt32(900)},
+			expectedMessages: 2,
+			check: func(t *testing.T, hardened management.PasswordPolicy) {
+				if hardened.History.GetCount() != 6 || hardened.History.GetRetentionDays() != 365 {
+					t.Errorf("expected history of 6 passwords for 365 days, got %d for %d days", hardened.History.GetCount(), hardened.History.GetRetentionDays())
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := management.PasswordPolicy{
+				Name:    "Test Policy",
+				Lockout: tt.lockout,
+				History: tt.history,
+			}
+
+			hardened, messages := hardenPasswordPolicy(baseline, policy)
+
+			if len(messages) != tt.expectedMessages {
+				t.Errorf("expected %d findings, got %d: %v", tt.expectedMessages, len(messages), messages)
+			}
+
+			if tt.check != nil {
+				tt.check(t, hardened)
+			}
+		})
+	}
+}
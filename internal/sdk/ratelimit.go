@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	requestRateLimiter = &RateLimiter{}
+)
+
+// RateLimiter spaces out requests to the PingOne API, so that concurrent callers share a single request budget.  A zero value RateLimiter does not limit requests.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// SetRequestRateLimit sets the maximum number of requests per second made to the PingOne API, across all callers.  A value of zero or less removes the limit.
+func SetRequestRateLimit(requestsPerSecond int) {
+	requestRateLimiter.mu.Lock()
+	defer requestRateLimiter.mu.Unlock()
+
+	if requestsPerSecond <= 0 {
+		requestRateLimiter.interval = 0
+		return
+	}
+
+	requestRateLimiter.interval = time.Second / time.Duration(requestsPerSecond)
+}
+
+// Wait blocks until the caller may make the next request, or the context is done
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	r.mu.Lock()
+
+	if r.interval == 0 {
+		r.mu.Unlock()
+		return nil
+	}
+
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+
+	wait := r.next.Sub(now)
+	r.next = r.next.Add(r.interval)
+
+	r.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -91,6 +91,10 @@ func RetryWrapper(ctx context.Context, timeout time.Duration, f SDKInterfaceFunc
 	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
 		var err error
 
+		if err = requestRateLimiter.Wait(ctx); err != nil {
+			return retry.NonRetryableError(err)
+		}
+
 		resp, r, err = f()
 
 		if err != nil || r.StatusCode >= 300 {